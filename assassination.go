@@ -0,0 +1,60 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotAssassin indicates that someone other than the assassin tried to
+	// name Merlin.
+	ErrNotAssassin = errors.New("avalon: only the assassin may assassinate")
+
+	// ErrNotServant indicates that someone other than the Untrustworthy
+	// Servant tried to tell the assassin who they are, or the servant tried
+	// twice.
+	ErrNotServant = errors.New("avalon: only the untrustworthy servant may join the assassin")
+)
+
+// Assassinate records the assassin's guess at Merlin and ends the game. Evil
+// wins if the target is Merlin, otherwise good survives.
+func (av *Avalon) Assassinate(assassin string, target string) error {
+	if err := av.expectPhase("assassinate", PhaseAssassination); err != nil {
+		return err
+	}
+
+	if assassin != av.Specials["assassin"] {
+		return ErrNotAssassin
+	}
+
+	if !av.PlayerExists(target) {
+		return ErrPlayerNotFound
+	}
+
+	av.record(Event{Type: EventAssassinationAttempted, Player: assassin, Target: target})
+	return nil
+}
+
+func (av *Avalon) applyAssassinationAttempted(ev Event) {
+	av.AssassinationTarget = ev.Target
+	av.Phase = PhaseGameOver
+}
+
+// TellAssassin has the Untrustworthy Servant reveal themselves to the assassin
+// before Merlin is named. From then on they are on the side of evil and win or
+// lose with it.
+func (av *Avalon) TellAssassin(servant string) error {
+	if err := av.expectPhase("tell the assassin", PhaseAssassination); err != nil {
+		return err
+	}
+
+	if servant == "" || servant != av.Specials["untrustworthyservant"] || av.ServantTurned {
+		return ErrNotServant
+	}
+
+	av.record(Event{Type: EventServantTurned, Player: servant})
+	return nil
+}
+
+func (av *Avalon) applyServantTurned(ev Event) {
+	av.ServantTurned = true
+}
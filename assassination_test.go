@@ -0,0 +1,73 @@
+package avalon
+
+import (
+	"testing"
+)
+
+func TestAssassinate(t *testing.T) {
+	var tests = []struct {
+		assassin string
+		target   string
+		want     error
+	}{
+		{"A", "B", ErrNotAssassin},
+		{"E", "Z", ErrPlayerNotFound},
+		{"E", "B", nil},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E"}
+		avalon.Specials = map[string]string{"merlin": "A", "assassin": "E"}
+		avalon.Phase = PhaseAssassination
+
+		err := avalon.Assassinate(test.assassin, test.target)
+		if err != test.want {
+			t.Errorf("wanted %v for %s naming %s, got %v", test.want, test.assassin, test.target, err)
+			continue
+		}
+
+		if err != nil {
+			if avalon.Phase != PhaseAssassination {
+				t.Errorf("rejected assassination changed phase to %s", avalon.Phase)
+			}
+			continue
+		}
+
+		if avalon.Phase != PhaseGameOver || avalon.AssassinationTarget != test.target {
+			t.Errorf("expected %s with target %s, got %s with %s",
+				PhaseGameOver, test.target, avalon.Phase, avalon.AssassinationTarget)
+		}
+	}
+}
+
+func TestTellAssassin(t *testing.T) {
+	avalon := NewAvalon()
+	avalon.Players = []string{"A", "B", "C", "D", "E"}
+	avalon.Seats = avalon.Players
+	avalon.Goods = []string{"A", "B", "C"}
+	avalon.Evils = []string{"D", "E"}
+	avalon.Specials = map[string]string{"merlin": "A", "untrustworthyservant": "B", "assassin": "E"}
+	avalon.Phase = PhaseAssassination
+
+	if err := avalon.TellAssassin("C"); err != ErrNotServant {
+		t.Errorf("wanted %v, got %v", ErrNotServant, err)
+	}
+	if err := avalon.TellAssassin("B"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avalon.TellAssassin("B"); err != ErrNotServant {
+		t.Errorf("wanted %v telling twice, got %v", ErrNotServant, err)
+	}
+	if !avalon.IsEvil("B") {
+		t.Errorf("expected the servant to have joined evil, got %s", avalon.LoyaltyOf("B"))
+	}
+
+	if err := avalon.Assassinate("E", "C"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	winners, _ := avalon.Winners()
+	if len(winners) != 2 || contains(winners, "B") {
+		t.Errorf("expected good to win without the servant, got %v", winners)
+	}
+}
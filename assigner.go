@@ -4,95 +4,209 @@ import (
 	"math/rand"
 )
 
+// Random is the source of randomness used to assign roles. A seeded
+// *rand.Rand satisfies it, so the same seed and the same players always
+// produce the same assignment.
+type Random interface {
+	Intn(n int) int
+	Perm(n int) []int
+}
+
+// globalRandom uses the top-level math/rand functions.
+type globalRandom struct{}
+
+func (globalRandom) Intn(n int) int   { return rand.Intn(n) }
+func (globalRandom) Perm(n int) []int { return rand.Perm(n) }
+
 // Assigner handles randomly assigning character roles to all players in a game.
 // Used by constructing one with an Avalon game and calling Assign(). The
 // Assigner will automatically populate the Avalon's fields.
 type Assigner struct {
-	Avalon *Avalon
+	Avalon   *Avalon
+	Random   Random
+	Strategy Strategy
 }
 
-// NewAssigner creates a new Assigner for the given Avalon game.
-func NewAssigner(avalon *Avalon) *Assigner {
+// NewAssigner creates a new Assigner for the given Avalon game that draws from
+// random, or from the global math/rand source if random is nil. It assigns
+// roles with RandomStrategy until Strategy is changed.
+func NewAssigner(avalon *Avalon, random Random) *Assigner {
+	if random == nil {
+		random = globalRandom{}
+	}
+
 	return &Assigner{
-		Avalon: avalon,
+		Avalon:   avalon,
+		Random:   random,
+		Strategy: RandomStrategy{},
 	}
 }
 
 // Assign should be called directly after creating a new Assigner. It populates
-// the member Avalon game directly with the assignments.
-func (ass *Assigner) Assign() {
-	ass.assignGoodEvil()
-	ass.assignSpecials()
+// the member Avalon game directly with the assignments, using the Assigner's
+// Strategy to decide who gets which role.
+func (ass *Assigner) Assign() error {
+	ass.assignSeats()
+	if err := ass.Strategy.AssignRoles(ass.Avalon, ass.Random); err != nil {
+		return err
+	}
 	ass.assignFirstLeaderAndLake()
+	ass.assignLancelotDeck()
+	ass.assignPlotDeck()
+
+	return nil
 }
 
-func (ass *Assigner) assignGoodEvil() {
-	randomOrder := rand.Perm(ass.Avalon.NumPlayers())
+// Players are seated around the table in a random order, regardless of the
+// order they joined.
+func (ass *Assigner) assignSeats() {
+	randomOrder := ass.Random.Perm(ass.Avalon.NumPlayers())
 
-	for i, n := range randomOrder {
-		nick := ass.Avalon.Players[n]
-		if i < ass.Avalon.NumGoods() {
-			ass.Avalon.Goods = append(ass.Avalon.Goods, nick)
-		} else {
-			ass.Avalon.Evils = append(ass.Avalon.Evils, nick)
-		}
+	ass.Avalon.Seats = make([]string, 0, ass.Avalon.NumPlayers())
+	for _, n := range randomOrder {
+		ass.Avalon.Seats = append(ass.Avalon.Seats, ass.Avalon.Players[n])
 	}
 }
 
-func (ass *Assigner) assignSpecials() {
-	// ==========
-	// == Good ==
-	// ==========
-	randomOrder := rand.Perm(ass.Avalon.NumGoods())
+func (ass *Assigner) assignFirstLeaderAndLake() {
+	// Assign random first leader
+	randLeader := ass.Random.Intn(ass.Avalon.NumPlayers())
+	ass.Avalon.CurrentLeader = ass.Avalon.Seats[randLeader]
 
-	// Merlin
-	ass.Avalon.Specials["merlin"] = ass.Avalon.Goods[randomOrder[0]]
+	// Lady of the Lake starts with the player to the first leader's right
+	if ass.Avalon.IsOptionEnabled("lake") {
+		ass.Avalon.CurrentLake = ass.Avalon.RightOf(ass.Avalon.CurrentLeader)
+	}
+}
 
-	// Percival
-	if ass.Avalon.IsOptionEnabled("morganapercival") {
-		ass.Avalon.Specials["percival"] = ass.Avalon.Goods[randomOrder[1]]
+// The Lancelot loyalty deck is shuffled along with the roles, so that the
+// switches are as fixed as who the Lancelots are.
+func (ass *Assigner) assignLancelotDeck() {
+	ass.Avalon.LancelotDeck = nil
+	if ass.Avalon.IsOptionEnabled("lancelot") {
+		ass.Avalon.LancelotDeck = shuffleLancelotDeck(ass.Random)
 	}
+}
+
+// The plot deck is shuffled up front as well.
+func (ass *Assigner) assignPlotDeck() {
+	ass.Avalon.PlotDeck = nil
+	if ass.Avalon.IsOptionEnabled("plot") {
+		ass.Avalon.PlotDeck = newPlotDeck(ass.Avalon.NumPlayers(), ass.Random)
+	}
+}
+
+// Assignment is the complete result of assigning roles for a game: where
+// everyone sits, who is good or evil, the special characters, the Lancelot
+// loyalty deck, the plot deck and who holds the first leadership and the Lady
+// of the Lake.
+type Assignment struct {
+	Seats        []string          `json:"seats"`
+	Goods        []string          `json:"goods"`
+	Evils        []string          `json:"evils"`
+	Specials     map[string]string `json:"specials"`
+	LancelotDeck []bool            `json:"lancelot_deck,omitempty"`
+	PlotDeck     []string          `json:"plot_deck,omitempty"`
+	Leader       string            `json:"leader"`
+	Lake         string            `json:"lake,omitempty"`
+}
 
-	// ==========
-	// == Evil ==
-	// ==========
-	randIndex := 1
-	randomOrder = rand.Perm(ass.Avalon.NumEvils())
+// assignment returns a copy of the game's current role assignment.
+func (av *Avalon) assignment() *Assignment {
+	specials := make(map[string]string, len(av.Specials))
+	for special, nick := range av.Specials {
+		specials[special] = nick
+	}
 
-	// Assassin
-	ass.Avalon.Specials["assassin"] = ass.Avalon.Evils[randomOrder[0]]
+	return &Assignment{
+		Seats:        copyStrings(av.Seats),
+		Goods:        copyStrings(av.Goods),
+		Evils:        copyStrings(av.Evils),
+		Specials:     specials,
+		LancelotDeck: copyBools(av.LancelotDeck),
+		PlotDeck:     copyStrings(av.PlotDeck),
+		Leader:       av.CurrentLeader,
+		Lake:         av.CurrentLake,
+	}
+}
 
-	// Mordred
-	if ass.Avalon.IsOptionEnabled("mordred") {
-		ass.Avalon.Specials["mordred"] = ass.Avalon.Evils[randomOrder[randIndex]]
-		randIndex++
+// copy returns a deep copy of the assignment, or nil if there is none.
+func (a *Assignment) copy() *Assignment {
+	if a == nil {
+		return nil
 	}
 
-	// Morgana
-	if ass.Avalon.IsOptionEnabled("morganapercival") {
-		ass.Avalon.Specials["morgana"] = ass.Avalon.Evils[randomOrder[randIndex]]
-		randIndex++
+	specials := make(map[string]string, len(a.Specials))
+	for special, nick := range a.Specials {
+		specials[special] = nick
 	}
 
-	// Oberon
-	if ass.Avalon.IsOptionEnabled("oberon") {
-		ass.Avalon.Specials["oberon"] = ass.Avalon.Evils[randomOrder[randIndex]]
-		randIndex++
+	return &Assignment{
+		Seats:        copyStrings(a.Seats),
+		Goods:        copyStrings(a.Goods),
+		Evils:        copyStrings(a.Evils),
+		Specials:     specials,
+		LancelotDeck: copyBools(a.LancelotDeck),
+		PlotDeck:     copyStrings(a.PlotDeck),
+		Leader:       a.Leader,
+		Lake:         a.Lake,
 	}
 }
 
-func (ass *Assigner) assignFirstLeaderAndLake() {
-	// Assign random first leader
-	randLeader := rand.Intn(ass.Avalon.NumPlayers())
-	ass.Avalon.CurrentLeader = ass.Avalon.Players[randLeader]
+// validateAssignment returns ErrInvalidAssignment unless the assignment could
+// have been dealt for this game: everyone seated once, the right number of
+// goods and evils, exactly the special characters in the game, each on their
+// own side, and the first leader and the Lady of the Lake at the table.
+func (av *Avalon) validateAssignment(assignment *Assignment) error {
+	everyone := append(copyStrings(assignment.Goods), assignment.Evils...)
+	if !sameMembers(assignment.Seats, av.Players) || !sameMembers(everyone, av.Players) ||
+		len(assignment.Goods) != av.NumGoods() || len(assignment.Evils) != av.NumEvils() {
+		return ErrInvalidAssignment
+	}
 
-	// Lady of the Lake, cannot be the first quest leader
-	if ass.Avalon.IsOptionEnabled("lake") {
-		randLake := rand.Intn(ass.Avalon.NumPlayers())
-		for randLake != randLeader {
-			randLake = rand.Intn(ass.Avalon.NumPlayers())
+	roles := av.RolesInGame()
+	if len(assignment.Specials) != len(roles) {
+		return ErrInvalidAssignment
+	}
+
+	dealt := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		side := assignment.Goods
+		if role.Loyalty == LoyaltyEvil {
+			side = assignment.Evils
 		}
 
-		ass.Avalon.CurrentLake = ass.Avalon.Players[randLake]
+		nick, ok := assignment.Specials[role.Name]
+		if !ok || dealt[nick] || !contains(side, nick) {
+			return ErrInvalidAssignment
+		}
+		dealt[nick] = true
+	}
+
+	if !contains(assignment.Seats, assignment.Leader) {
+		return ErrInvalidAssignment
+	}
+
+	if av.IsOptionEnabled("lake") != contains(assignment.Seats, assignment.Lake) {
+		return ErrInvalidAssignment
+	}
+
+	return nil
+}
+
+// setAssignment replaces the game's role assignment with a copy of the given
+// one.
+func (av *Avalon) setAssignment(assignment *Assignment) {
+	av.Seats = copyStrings(assignment.Seats)
+	av.Goods = copyStrings(assignment.Goods)
+	av.Evils = copyStrings(assignment.Evils)
+	av.Specials = make(map[string]string, len(assignment.Specials))
+	for special, nick := range assignment.Specials {
+		av.Specials[special] = nick
 	}
+	av.LancelotDeck = copyBools(assignment.LancelotDeck)
+	av.PlotDeck = copyStrings(assignment.PlotDeck)
+	av.CurrentLeader = assignment.Leader
+	av.FirstLeader = assignment.Leader
+	av.CurrentLake = assignment.Lake
 }
@@ -1,6 +1,8 @@
 package avalon
 
 import (
+	"math/rand"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -41,13 +43,113 @@ func TestAssign(t *testing.T) {
 		avalon.Players = test.players
 		avalon.AvalonConfig.OptionsEnabled = test.options
 
-		ass := NewAssigner(avalon)
-		ass.Assign()
+		ass := NewAssigner(avalon, nil)
+		if err := ass.Assign(); err != nil {
+			t.Fatalf("unexpected error assigning: %v", err)
+		}
 
 		runRequirementTests(t, avalon)
 	}
 }
 
+// The Lady of the Lake always starts with the player to the first leader's
+// right, for every player count where lake is allowed.
+func TestAssignLake(t *testing.T) {
+	players := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+
+	for n := 7; n <= 10; n++ {
+		for i := 0; i < 20; i++ {
+			avalon := NewAvalon()
+			avalon.Players = players[:n]
+			avalon.EnableOption("lake")
+
+			ass := NewAssigner(avalon, nil)
+			if err := ass.Assign(); err != nil {
+				t.Fatalf("unexpected error assigning: %v", err)
+			}
+
+			leader := avalon.seatOf(avalon.CurrentLeader)
+			want := avalon.Seats[(leader+n-1)%n]
+			if avalon.CurrentLake != want {
+				t.Errorf("expected lake with %s, right of leader %s in %v, got %s",
+					want, avalon.CurrentLeader, avalon.Seats, avalon.CurrentLake)
+			}
+			if avalon.CurrentLake == avalon.CurrentLeader {
+				t.Errorf("first leader and lake are same with %d players: %s", n, avalon.CurrentLeader)
+			}
+		}
+	}
+}
+
+// The same seed and the same players always produce the same assignment.
+func TestAssignSeeded(t *testing.T) {
+	players := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+	options := []string{"lake", "mordred", "morganapercival", "oberon"}
+
+	assign := func(seed int64) *Assignment {
+		avalon := NewAvalon()
+		avalon.Players = players
+		avalon.EnableMany(options)
+
+		ass := NewAssigner(avalon, rand.New(rand.NewSource(seed)))
+		if err := ass.Assign(); err != nil {
+			t.Fatalf("unexpected error assigning: %v", err)
+		}
+
+		runRequirementTests(t, avalon)
+		return avalon.assignment()
+	}
+
+	for seed := int64(0); seed < 10; seed++ {
+		first, second := assign(seed), assign(seed)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("seed %d gave different assignments: %+v, %+v", seed, first, second)
+		}
+	}
+
+	if reflect.DeepEqual(assign(1), assign(2)) {
+		t.Error("different seeds gave the same assignment")
+	}
+}
+
+func TestStartSeeded(t *testing.T) {
+	start := func() *Avalon {
+		av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"lake"})
+		av.SetRandom(rand.New(rand.NewSource(42)))
+		if err := av.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return av
+	}
+
+	first, second := start(), start()
+	if !reflect.DeepEqual(first.assignment(), second.assignment()) {
+		t.Errorf("seeded games started differently: %+v, %+v", first.assignment(), second.assignment())
+	}
+}
+
+// Seats are shuffled rather than following join order. With 10 players the
+// chance of every one of these assignments keeping join order is negligible.
+func TestAssignSeats(t *testing.T) {
+	players := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+
+	for i := 0; i < 10; i++ {
+		avalon := NewAvalon()
+		avalon.Players = players
+
+		ass := NewAssigner(avalon, nil)
+		if err := ass.Assign(); err != nil {
+			t.Fatalf("unexpected error assigning: %v", err)
+		}
+
+		if !reflect.DeepEqual(avalon.Seats, avalon.Players) {
+			return
+		}
+	}
+
+	t.Errorf("seats always matched join order: %v", players)
+}
+
 func runRequirementTests(t *testing.T, av *Avalon) {
 	numPlayers := len(av.Players)
 	numGoodsAssigned := len(av.Goods)
@@ -134,10 +236,16 @@ func runRequirementTests(t *testing.T, av *Avalon) {
 
 	// Lake should be a player
 	if av.IsOptionEnabled("lake") {
-		if !av.PlayerExists(av.CurrentLeader) {
+		if !av.PlayerExists(av.CurrentLake) {
+			t.Errorf("lake is not a player: %s", av.CurrentLake)
 		}
 	}
 
+	// Every player should have exactly one seat
+	if !setsEqual(av.Seats, av.Players) {
+		t.Errorf("seats do not match players; seats: %v, players: %v", av.Seats, av.Players)
+	}
+
 	// If lake is enabled, The Lady of the Lake should be assigned
 	if av.IsOptionEnabled("lake") {
 		if av.CurrentLake == "" {
@@ -147,7 +255,7 @@ func runRequirementTests(t *testing.T, av *Avalon) {
 
 	// First leader should never be the same as lake
 	if av.IsOptionEnabled("lake") {
-		if av.CurrentLeader == av.Specials["lake"] {
+		if av.CurrentLeader == av.CurrentLake {
 			t.Errorf("first leader and lake are same: %s", av.CurrentLeader)
 		}
 	}
@@ -4,6 +4,7 @@ package avalon
 
 import (
 	"errors"
+	"strings"
 )
 
 var (
@@ -14,6 +15,22 @@ var (
 	// ErrTooManyPlayers indiciates that a player is trying to join when
 	// there are already 10 players.
 	ErrTooManyPlayers = errors.New("avalon: there are already 10 players")
+
+	// ErrNotEnoughPlayers indicates that the game cannot start because fewer
+	// than MinPlayers have joined.
+	ErrNotEnoughPlayers = errors.New("avalon: there are not enough players to start")
+
+	// ErrPlayerNotFound indicates that an action named a player who is not in
+	// the game.
+	ErrPlayerNotFound = errors.New("avalon: player is not in this game")
+
+	// ErrUnknownOption indicates that an option that does not exist was
+	// enabled.
+	ErrUnknownOption = errors.New("avalon: there is no such option")
+
+	// ErrOptionNotEnabled indicates that an option that is not enabled was
+	// disabled.
+	ErrOptionNotEnabled = errors.New("avalon: option is not enabled")
 )
 
 // | Players | Evils | Q1 | Q2 | Q3 | Q4 | Q5 |
@@ -25,29 +42,88 @@ var (
 // |       9 |     3 |  3 |  4 |  4 |  5 |  5 |
 // |      10 |     4 |  3 |  4 |  4 |  5 |  5 |
 
-// With 7+ players, Quest 4 requires two fails to fail.
+// With 7+ players, Quest 4 requires two fails to fail unless the singlefail
+// option is enabled.
 
 // Avalon represents underlying game state and facilitates changes to the game
 // as it progresses and win conditions.
 type Avalon struct {
 	*AvalonConfig
 
-	// Players and their roles
+	// Players and their roles. Seats lists the players clockwise around the
+	// table.
 	Players  []string
+	Seats    []string
 	Goods    []string
 	Evils    []string
 	Specials map[string]string
 
+	// The shuffled Lancelot loyalty deck, true for each switch card, and how
+	// many of its cards have been flipped
+	LancelotDeck  []bool
+	LancelotFlips int
+
+	// The shuffled plot deck and how many of its cards have been drawn
+	PlotDeck  []string
+	PlotDrawn int
+
+	// Who led first, which the Cleric learns about, and what they chose to
+	// show the Cleric if they may lie
+	FirstLeader      string
+	FirstLeaderShown Loyalty
+
+	// Hash of the role assignment published when the game starts. The salt
+	// that opens it stays hidden until the game is over.
+	Commitment string
+	salt       string
+
 	// Game state information that changes throughout the game's lifecycle
+	Phase                Phase
 	CurrentQuest         int
+	CurrentTarget        int
 	CurrentLake          string
 	CurrentLeader        string
 	CurrentProposedParty []string
+	CurrentExcalibur     string
+	CurrentVotes         map[string]bool
+	questCardsPlayed     map[string]bool // nick -> success
+	questFailsPlayed     int
 	VoteTrack            int
 
-	// Information about past quests
-	QuestSuccesses   []bool
-	PastQuestParties []string
+	// Plot cards the current leader has drawn but not dealt, and the plot
+	// cards each player holds
+	CurrentPlotCards []string
+	PlotHands        map[string][]string
+
+	// Information about past quests, in the order they were attempted
+	QuestHistory []QuestRecord
+	LakeUses     []LakeUse
+	PlotUses     []PlotUse
+
+	// The player the assassin named once good completed three quests, and
+	// whether the Untrustworthy Servant told the assassin who they are first
+	AssassinationTarget string
+	ServantTurned       bool
+
+	// A player who may lie and is being investigated, who must choose which
+	// loyalty to show before the game goes on
+	PendingAnswer string
+
+	// With the hunter option, the leader who may investigate a member of the
+	// party that just played chief cards, everyone investigated so far, and
+	// the hunter who accused a chief to end the game
+	CurrentInvestigator string
+	Investigations      []Investigation
+	Accuser             string
+	AccusationTarget    string
+
+	// Every event that has happened in the game, in order
+	events []Event
+
+	// Source of randomness for assigning roles, nil for the global source,
+	// and how roles are matched to players, nil for RandomStrategy
+	random   Random
+	strategy Strategy
 }
 
 // NewAvalon sets up a new Avalon game with no config options enabled.
@@ -95,8 +171,12 @@ func (av *Avalon) PlayerExists(nick string) bool {
 }
 
 // AddPlayer attempts to add a new player to the list of players. Errors if the
-// player already exists or they are too many players.
+// player already exists, they are too many players or the game has started.
 func (av *Avalon) AddPlayer(nick string) error {
+	if err := av.expectPhase("add a player", PhaseLobby); err != nil {
+		return err
+	}
+
 	if av.NumPlayers() >= 10 {
 		return ErrTooManyPlayers
 	}
@@ -105,10 +185,88 @@ func (av *Avalon) AddPlayer(nick string) error {
 		return ErrPlayerExists
 	}
 
-	av.Players = append(av.Players, nick)
+	av.record(Event{Type: EventPlayerJoined, Player: nick})
 	return nil
 }
 
+func (av *Avalon) applyPlayerJoined(ev Event) {
+	av.Players = append(av.Players, ev.Player)
+}
+
+// SetRandom sets the source of randomness used to assign roles when the game
+// starts. Passing a seeded *rand.Rand makes the assignment reproducible.
+func (av *Avalon) SetRandom(random Random) {
+	av.random = random
+}
+
+// SetStrategy sets how roles are matched to players when the game starts.
+func (av *Avalon) SetStrategy(strategy Strategy) {
+	av.strategy = strategy
+}
+
+// EnableOption enables an option while the game is still in the lobby. Once
+// the game has started its options cannot change.
+func (av *Avalon) EnableOption(option string) {
+	option = strings.ToLower(option)
+	if av.canEnableOption(option) != nil {
+		return
+	}
+
+	av.record(Event{Type: EventOptionEnabled, Option: option})
+}
+
+// canEnableOption returns why the option cannot be enabled right now, if it
+// cannot.
+func (av *Avalon) canEnableOption(option string) error {
+	if err := av.expectPhase("enable an option", PhaseLobby); err != nil {
+		return err
+	}
+
+	if !OptionExists(option) {
+		return ErrUnknownOption
+	}
+
+	return nil
+}
+
+// EnableMany makes a best-effort attempt to enable every option requested.
+func (av *Avalon) EnableMany(options []string) {
+	for _, option := range options {
+		av.EnableOption(option)
+	}
+}
+
+// DisableOption disables an option while the game is still in the lobby.
+func (av *Avalon) DisableOption(option string) {
+	option = strings.ToLower(option)
+	if av.canDisableOption(option) != nil {
+		return
+	}
+
+	av.record(Event{Type: EventOptionDisabled, Option: option})
+}
+
+// canDisableOption returns why the option cannot be disabled right now, if it
+// cannot.
+func (av *Avalon) canDisableOption(option string) error {
+	if err := av.expectPhase("disable an option", PhaseLobby); err != nil {
+		return err
+	}
+
+	if !av.IsOptionEnabled(option) {
+		return ErrOptionNotEnabled
+	}
+
+	return nil
+}
+
+// DisableMany makes a best-effort attempt to disable every option requested.
+func (av *Avalon) DisableMany(options []string) {
+	for _, option := range options {
+		av.DisableOption(option)
+	}
+}
+
 // IsValid overrides the AvalonConfig IsValid and does not require a numPlayers
 // to be passed in.
 func (av *Avalon) IsValid() error {
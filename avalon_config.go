@@ -81,11 +81,39 @@ func (ac *AvalonConfig) allEnabledOptions() []string {
 	return options
 }
 
+// RolesInGame returns every special character in a game with this config, in
+// the order they are assigned. In resistance mode, the roles in every game of
+// Avalon, Merlin and the Assassin, are left out.
+func (ac *AvalonConfig) RolesInGame() []Role {
+	var roles []Role
+	for _, role := range registeredRoles {
+		if (role.Option == "" && !ac.IsOptionEnabled("resistance")) || ac.IsOptionEnabled(role.Option) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// specialsInGame returns the names of the special characters on the given side
+// in a game with this config, in the order they are assigned.
+func (ac *AvalonConfig) specialsInGame(loyalty Loyalty) []string {
+	var names []string
+	for _, role := range ac.RolesInGame() {
+		if role.Loyalty == loyalty {
+			names = append(names, role.Name)
+		}
+	}
+
+	return names
+}
+
 // NumEvilSpecials returns the number of special evil characters enabled.
+// Characters that are in every game, like the Assassin, are not counted.
 func (ac *AvalonConfig) NumEvilSpecials() int {
 	var count int
-	for _, option := range specialEvilOptions {
-		if ac.IsOptionEnabled(option) {
+	for _, role := range ac.RolesInGame() {
+		if role.Loyalty == LoyaltyEvil && role.Option != "" {
 			count++
 		}
 	}
@@ -105,21 +133,69 @@ func (ac *AvalonConfig) IsValid(numPlayers int) error {
 		}
 	}
 
-	// No Oberon until 10 players
-	if ac.IsOptionEnabled("oberon") {
-		if numPlayers < 10 {
-			errorStrings = append(errorStrings, "oberon requires 10 players")
+	// The Resistance has none of the Arthurian characters
+	if ac.IsOptionEnabled("resistance") {
+		for _, option := range roleOptions() {
+			if ac.IsOptionEnabled(option) && !isResistanceOption(option) {
+				errorStrings = append(errorStrings, fmt.Sprintf("resistance cannot be played with %s", option))
+			}
+		}
+	}
+
+	// Roles that need a minimum number of players, e.g. no Oberon until 10
+	for _, option := range roleOptions() {
+		if !ac.IsOptionEnabled(option) {
+			continue
+		}
+
+		var minPlayers int
+		for _, role := range ac.RolesInGame() {
+			if role.Option == option && role.MinPlayers > minPlayers {
+				minPlayers = role.MinPlayers
+			}
+		}
+
+		if numPlayers < minPlayers {
+			errorStrings = append(errorStrings, fmt.Sprintf("%s requires %d players", option, minPlayers))
+		}
+	}
+
+	// Roles that are pointless without another option, e.g. nobody
+	// investigates the Trickster without the Lady of the Lake or the Cleric
+	unmet := make(map[string]bool)
+	for _, role := range ac.RolesInGame() {
+		if len(role.Requires) == 0 || unmet[role.Option] {
+			continue
+		}
+
+		var ok bool
+		for _, option := range role.Requires {
+			ok = ok || ac.IsOptionEnabled(option)
+		}
+		if !ok {
+			unmet[role.Option] = true
+			errorStrings = append(errorStrings, fmt.Sprintf("%s requires %s", role.Option, strings.Join(role.Requires, " or ")))
+		}
+	}
+
+	// Lancelot variants only make sense with the Lancelots in the game
+	for _, variant := range []string{"lancelotsknow", "lancelotreveal"} {
+		if ac.IsOptionEnabled(variant) && !ac.IsOptionEnabled("lancelot") {
+			errorStrings = append(errorStrings, fmt.Sprintf("%s requires lancelot", variant))
 		}
 	}
 
-	// Keep at least one evil for Assassin
+	// Every special character needs a player on their side
 	numEvils := numEvils(numPlayers)
-	numSpecials := ac.NumEvilSpecials()
-	if numSpecials >= numEvils {
-		n := numSpecials - numEvils + 1
+	if n := len(ac.specialsInGame(LoyaltyEvil)) - numEvils; n > 0 {
 		errorStrings = append(errorStrings, fmt.Sprintf("you have %d too many evils", n))
 	}
 
+	numGoods := numPlayers - numEvils
+	if n := len(ac.specialsInGame(LoyaltyGood)) - numGoods; n > 0 && numEvils > 0 {
+		errorStrings = append(errorStrings, fmt.Sprintf("you have %d too many goods", n))
+	}
+
 	if len(errorStrings) > 0 {
 		return errors.New(strings.Join(errorStrings, "; "))
 	}
@@ -349,6 +349,54 @@ func TestIsValid(t *testing.T) {
 			false,
 			"",
 		},
+		{
+			7,
+			map[string]bool{"trickster": true, "troublemaker": true},
+			true,
+			"troublemaker requires lake or cleric; trickster requires lake or cleric",
+		},
+		{
+			7,
+			map[string]bool{"trickster": true, "troublemaker": true, "cleric": true},
+			false,
+			"",
+		},
+		{
+			7,
+			map[string]bool{"lancelotsknow": true},
+			true,
+			"lancelotsknow requires lancelot",
+		},
+		{
+			5,
+			map[string]bool{"lancelot": true, "lancelotsknow": true, "lancelotreveal": true},
+			false,
+			"",
+		},
+		{
+			5,
+			map[string]bool{"lancelot": true, "mordred": true},
+			true,
+			"you have 1 too many evils",
+		},
+		{
+			7,
+			map[string]bool{"hunter": true},
+			true,
+			"hunter requires resistance",
+		},
+		{
+			5,
+			map[string]bool{"resistance": true, "dummyagent": true},
+			true,
+			"dummyagent requires hunter",
+		},
+		{
+			5,
+			map[string]bool{"resistance": true, "hunter": true, "dummyagent": true},
+			false,
+			"",
+		},
 		{
 			6,
 			map[string]bool{"lake": true, "mordred": true, "morganapercival": true, "oberon": true},
@@ -0,0 +1,78 @@
+package avalon
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Revealing the assignment at the end of the game lets anyone check that it
+// matches the Commitment published when the game started:
+//
+//	assignment, salt, err := av.Reveal()
+//	ok := VerifyAssignment(commitment, salt, assignment)
+
+// saltBytes is the number of random bytes in each game's salt, enough that
+// the assignment cannot be brute forced from the commitment.
+const saltBytes = 32
+
+// newSalt returns a fresh hex-encoded salt from crypto/rand.
+func newSalt() string {
+	b := make([]byte, saltBytes)
+	if _, err := rand.Read(b); err != nil {
+		panic("avalon: secure random source failed: " + err.Error())
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// roles returns a copy of the assignment without the first leader and Lady of
+// the Lake. Both move during the game, so the commitment only covers seats,
+// roles and the decks, which must not.
+func (a *Assignment) roles() *Assignment {
+	return &Assignment{
+		Seats:        a.Seats,
+		Goods:        a.Goods,
+		Evils:        a.Evils,
+		Specials:     a.Specials,
+		LancelotDeck: a.LancelotDeck,
+		PlotDeck:     a.PlotDeck,
+	}
+}
+
+// commit returns the hex-encoded SHA-256 of the salt followed by the JSON
+// encoding of the assignment's seats and roles.
+func commit(salt string, assignment *Assignment) string {
+	// Marshalling an Assignment cannot fail and map keys are always sorted,
+	// so the encoding is canonical.
+	data, _ := json.Marshal(assignment.roles())
+
+	h := sha256.New()
+	h.Write([]byte(salt))
+	h.Write([]byte{':'})
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAssignment reports whether the assignment and salt revealed at the end
+// of a game match the commitment published at its start.
+func VerifyAssignment(commitment string, salt string, assignment *Assignment) bool {
+	if assignment == nil {
+		return false
+	}
+
+	return commit(salt, assignment) == commitment
+}
+
+// Reveal returns the seats and roles the game ended with and the salt needed
+// to check them against the Commitment. If anyone changed a role mid-game the
+// check fails. Errors if the game is not over yet, since the salt would let
+// players confirm guesses about each other's roles.
+func (av *Avalon) Reveal() (*Assignment, string, error) {
+	if av.Phase != PhaseGameOver {
+		return nil, "", ErrGameNotOver
+	}
+
+	return av.assignment().roles(), av.salt, nil
+}
@@ -0,0 +1,72 @@
+package avalon
+
+import (
+	"testing"
+)
+
+func TestCommitReveal(t *testing.T) {
+	av := playFullGame(t)
+
+	if len(av.Commitment) != 64 {
+		t.Fatalf("expected a hex sha256 commitment, got %q", av.Commitment)
+	}
+
+	assignment, salt, err := av.Reveal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !VerifyAssignment(av.Commitment, salt, assignment) {
+		t.Errorf("revealed assignment does not match commitment: %+v", assignment)
+	}
+
+	// Any change to the roles or salt breaks the commitment
+	if VerifyAssignment(av.Commitment, salt+"0", assignment) {
+		t.Error("commitment verified with the wrong salt")
+	}
+
+	tampered := av.assignment().roles()
+	tampered.Specials = map[string]string{"merlin": tampered.Specials["assassin"], "assassin": tampered.Specials["merlin"]}
+	if VerifyAssignment(av.Commitment, salt, tampered) {
+		t.Error("commitment verified with swapped specials")
+	}
+
+	if VerifyAssignment(av.Commitment, salt, nil) {
+		t.Error("commitment verified with no assignment")
+	}
+}
+
+// Roles changed mid-game by an operator are caught at reveal.
+func TestRevealAfterTampering(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	commitment := av.Commitment
+
+	for _, good := range av.Goods {
+		if good != av.Specials["merlin"] {
+			av.Specials["merlin"] = good
+			break
+		}
+	}
+	av.Phase = PhaseGameOver
+
+	assignment, salt, _ := av.Reveal()
+	if VerifyAssignment(commitment, salt, assignment) {
+		t.Error("tampered assignment verified against the original commitment")
+	}
+}
+
+func TestRevealBeforeGameOver(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if av.Commitment == "" {
+		t.Error("expected commitment to be published at start")
+	}
+	if _, _, err := av.Reveal(); err != ErrGameNotOver {
+		t.Errorf("wanted %v, got %v", ErrGameNotOver, err)
+	}
+}
@@ -19,20 +19,36 @@ var (
 		10: {3, 4, 4, 5, 5},
 	}
 
-	specialCharacterToFlavorText = map[string]string{
-		"assassin": "You are on the prowl for Merlin. If he reveals himself, you will kill him.",
-		"merlin":   "You see all evils except Mordred. You must keep yourself hidden from Assassin.",
-		"mordred":  "You remain unknown to Merlin.",
-		"morgana":  "You appear as Merlin to Percival.",
-		"percival": "You see Merlin's identity, but Morgana attempts to trick you.",
-		"oberon":   "You are unknown to the other evils and you do not know them.",
+	// With 7+ players, Quest 4 requires two fails to fail.
+	numPlayersToFailsRequired = map[int][]int{
+		5:  {1, 1, 1, 1, 1},
+		6:  {1, 1, 1, 1, 1},
+		7:  {1, 1, 1, 2, 1},
+		8:  {1, 1, 1, 2, 1},
+		9:  {1, 1, 1, 2, 1},
+		10: {1, 1, 1, 2, 1},
 	}
 
-	availableOptions   = []string{"lake", "mordred", "morganapercival", "oberon"}
-	specialEvilOptions = []string{"mordred", "morganapercival", "oberon"}
+	// With the plot option, the number of plot cards each leader draws.
+	numPlayersToPlotCards = map[int]int{
+		5:  1,
+		6:  1,
+		7:  2,
+		8:  2,
+		9:  3,
+		10: 3,
+	}
+
+	// Options that change the rules rather than add a role. Options for roles
+	// come from the role registry.
+	availableOptions = []string{"lake", "singlefail", "secure", "lancelotsknow", "lancelotreveal", "excalibur", "plot", "targeting", "resistance"}
 )
 
 const (
 	// MinPlayers is the minimum number of players required to start a game.
 	MinPlayers = 5
+
+	// MaxRejections is the number of consecutive rejected parties on a single
+	// quest that hands the game to evil.
+	MaxRejections = 5
 )
@@ -0,0 +1,210 @@
+package avalon
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownEvent indicates that an event of an unrecognized type was
+	// applied to a game.
+	ErrUnknownEvent = errors.New("avalon: unknown event type")
+
+	// ErrMissingAssignment indicates that a RolesAssigned event did not carry
+	// the assignment it records.
+	ErrMissingAssignment = errors.New("avalon: roles assigned event has no assignment")
+
+	// ErrInvalidAssignment indicates that a RolesAssigned event carried an
+	// assignment that could not have been dealt for the game.
+	ErrInvalidAssignment = errors.New("avalon: roles assigned do not fit this game")
+)
+
+// EventType identifies what happened in an Event.
+type EventType string
+
+// Every kind of event that can happen in a game.
+const (
+	EventPlayerJoined           EventType = "player_joined"
+	EventOptionEnabled          EventType = "option_enabled"
+	EventOptionDisabled         EventType = "option_disabled"
+	EventRolesAssigned          EventType = "roles_assigned"
+	EventQuestsBegun            EventType = "quests_begun"
+	EventPartyProposed          EventType = "party_proposed"
+	EventVoteCast               EventType = "vote_cast"
+	EventVotingClosed           EventType = "voting_closed"
+	EventQuestCardPlayed        EventType = "quest_card_played"
+	EventExcaliburUsed          EventType = "excalibur_used"
+	EventExcaliburDeclined      EventType = "excalibur_declined"
+	EventPlotCardDealt          EventType = "plot_card_dealt"
+	EventPlotCardUsed           EventType = "plot_card_used"
+	EventResponsibilityTaken    EventType = "responsibility_taken"
+	EventLakeUsed               EventType = "lake_used"
+	EventLakeClaimed            EventType = "lake_claimed"
+	EventInvestigationAnswered  EventType = "investigation_answered"
+	EventChiefInvestigated      EventType = "chief_investigated"
+	EventChiefAccused           EventType = "chief_accused"
+	EventServantTurned          EventType = "servant_turned"
+	EventAssassinationAttempted EventType = "assassination_attempted"
+)
+
+// Event is a single entry in a game's append-only log. Player is whoever
+// acted, and only the fields relevant to the event's type are set.
+type Event struct {
+	Type       EventType   `json:"type"`
+	Player     string      `json:"player,omitempty"`
+	Target     string      `json:"target,omitempty"`
+	Option     string      `json:"option,omitempty"`
+	Quest      int         `json:"quest,omitempty"`
+	Party      []string    `json:"party,omitempty"`
+	Approve    bool        `json:"approve,omitempty"`
+	Success    bool        `json:"success,omitempty"`
+	Claim      Loyalty     `json:"claim,omitempty"`
+	Card       string      `json:"card,omitempty"`
+	Assignment *Assignment `json:"assignment,omitempty"`
+	Salt       string      `json:"salt,omitempty"`
+}
+
+// Events returns a copy of every event that has happened in the game, in
+// order. Storing these is enough to rebuild the game with Replay.
+func (av *Avalon) Events() []Event {
+	events := make([]Event, len(av.events))
+	for i, ev := range av.events {
+		ev.Party = copyStrings(ev.Party)
+		ev.Assignment = ev.Assignment.copy()
+		events[i] = ev
+	}
+
+	return events
+}
+
+// record appends an already validated event to the log and applies it.
+func (av *Avalon) record(ev Event) {
+	av.events = append(av.events, ev)
+	av.apply(ev)
+}
+
+// apply folds a single event into the game state. It does no validation, so
+// it must only ever see events that have already been checked.
+func (av *Avalon) apply(ev Event) {
+	switch ev.Type {
+	case EventPlayerJoined:
+		av.applyPlayerJoined(ev)
+	case EventOptionEnabled:
+		av.AvalonConfig.EnableOption(ev.Option)
+	case EventOptionDisabled:
+		av.AvalonConfig.DisableOption(ev.Option)
+	case EventRolesAssigned:
+		av.applyRolesAssigned(ev)
+	case EventQuestsBegun:
+		av.applyQuestsBegun(ev)
+	case EventPartyProposed:
+		av.applyPartyProposed(ev)
+	case EventVoteCast:
+		av.applyVoteCast(ev)
+	case EventVotingClosed:
+		av.applyVotingClosed(ev)
+	case EventQuestCardPlayed:
+		av.applyQuestCardPlayed(ev)
+	case EventExcaliburUsed:
+		av.applyExcaliburUsed(ev)
+	case EventExcaliburDeclined:
+		av.applyExcaliburDeclined(ev)
+	case EventPlotCardDealt:
+		av.applyPlotCardDealt(ev)
+	case EventPlotCardUsed:
+		av.applyPlotCardUsed(ev)
+	case EventResponsibilityTaken:
+		av.applyResponsibilityTaken(ev)
+	case EventLakeUsed:
+		av.applyLakeUsed(ev)
+	case EventLakeClaimed:
+		av.applyLakeClaimed(ev)
+	case EventInvestigationAnswered:
+		av.applyInvestigationAnswered(ev)
+	case EventChiefInvestigated:
+		av.applyChiefInvestigated(ev)
+	case EventChiefAccused:
+		av.applyChiefAccused(ev)
+	case EventServantTurned:
+		av.applyServantTurned(ev)
+	case EventAssassinationAttempted:
+		av.applyAssassinationAttempted(ev)
+	}
+}
+
+// Apply validates an event against the current state exactly as if the action
+// it records had just been taken, then records it.
+func (av *Avalon) Apply(ev Event) error {
+	switch ev.Type {
+	case EventPlayerJoined:
+		return av.AddPlayer(ev.Player)
+	case EventOptionEnabled:
+		if err := av.canEnableOption(ev.Option); err != nil {
+			return err
+		}
+		av.EnableOption(ev.Option)
+	case EventOptionDisabled:
+		if err := av.canDisableOption(ev.Option); err != nil {
+			return err
+		}
+		av.DisableOption(ev.Option)
+	case EventRolesAssigned:
+		return av.startWith(ev.Assignment, ev.Salt)
+	case EventQuestsBegun:
+		return av.BeginQuests()
+	case EventPartyProposed:
+		return av.Propose(ev.Player, PartyProposal{Party: ev.Party, Excalibur: ev.Target, Quest: ev.Quest})
+	case EventVoteCast:
+		return av.Vote(ev.Player, ev.Approve)
+	case EventVotingClosed:
+		return av.CloseVoting()
+	case EventQuestCardPlayed:
+		return av.PlayQuestCard(ev.Player, ev.Success)
+	case EventExcaliburUsed:
+		_, err := av.UseExcalibur(ev.Player, ev.Target)
+		return err
+	case EventExcaliburDeclined:
+		return av.DeclineExcalibur(ev.Player)
+	case EventPlotCardDealt:
+		return av.DealPlotCard(ev.Player, ev.Card, ev.Target)
+	case EventPlotCardUsed:
+		_, err := av.UsePlotCard(ev.Player, ev.Card, ev.Target)
+		return err
+	case EventResponsibilityTaken:
+		return av.TakeResponsibility(ev.Player, ev.Target, ev.Card)
+	case EventLakeUsed:
+		_, err := av.UseLake(ev.Player, ev.Target)
+		return err
+	case EventLakeClaimed:
+		return av.ClaimLake(ev.Player, ev.Claim)
+	case EventInvestigationAnswered:
+		return av.AnswerInvestigation(ev.Player, ev.Claim)
+	case EventChiefInvestigated:
+		_, err := av.Investigate(ev.Player, ev.Target)
+		return err
+	case EventChiefAccused:
+		return av.Accuse(ev.Player, ev.Target)
+	case EventServantTurned:
+		return av.TellAssassin(ev.Player)
+	case EventAssassinationAttempted:
+		return av.Assassinate(ev.Player, ev.Target)
+	default:
+		return ErrUnknownEvent
+	}
+
+	return nil
+}
+
+// Replay rebuilds a game from its event log. Every event is validated as it is
+// applied, so replaying a log reproduces the game exactly or reports the first
+// event that could not have happened.
+func Replay(events []Event) (*Avalon, error) {
+	av := NewAvalon()
+	for i, ev := range events {
+		if err := av.Apply(ev); err != nil {
+			return nil, fmt.Errorf("avalon: replaying event %d (%s): %w", i, ev.Type, err)
+		}
+	}
+
+	return av, nil
+}
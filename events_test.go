@@ -0,0 +1,253 @@
+package avalon
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func playFullGame(t *testing.T) *Avalon {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"lake", "morganapercival"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, false)
+	playQuest(t, av, true)
+	playQuest(t, av, false)
+
+	holder := av.CurrentLake
+	if _, err := av.UseLake(holder, av.LeftOf(holder)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ClaimLake(holder, LoyaltyGood); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	playQuest(t, av, true)
+	holder = av.CurrentLake
+	if _, err := av.UseLake(holder, av.LeftOf(holder)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.Vote("A", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.CloseVoting(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	for _, nick := range av.CurrentProposedParty {
+		if err := av.PlayQuestCard(nick, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := av.Assassinate(av.Specials["assassin"], av.Specials["percival"]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if av.Phase != PhaseGameOver {
+		t.Fatalf("expected game to be over, got %s", av.Phase)
+	}
+
+	return av
+}
+
+func TestReplay(t *testing.T) {
+	av := playFullGame(t)
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+
+	// The log survives being written out and read back in
+	data, err := json.Marshal(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replayed, err = Replay(events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("game replayed from JSON differs from original")
+	}
+
+	want, _ := av.Result()
+	if result, _ := replayed.Result(); result != want {
+		t.Errorf("wanted %s, got %s", want, result)
+	}
+}
+
+func TestEventsCopy(t *testing.T) {
+	av := playFullGame(t)
+	before, err := json.Marshal(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, ev := range av.Events() {
+		if ev.Assignment != nil {
+			ev.Assignment.Specials["merlin"] = "Z"
+			ev.Assignment.Seats[0] = "Z"
+		}
+		if len(ev.Party) > 0 {
+			ev.Party[0] = "Z"
+		}
+	}
+
+	after, err := json.Marshal(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(after) != string(before) {
+		t.Error("changing the returned events changed the game's log")
+	}
+}
+
+// Replaying a prefix of the log rebuilds the game as it was at that point.
+func TestReplayPrefix(t *testing.T) {
+	av := playFullGame(t)
+	events := av.Events()
+
+	for n := range events {
+		if _, err := Replay(events[:n]); err != nil {
+			t.Fatalf("unexpected error replaying first %d events: %v", n, err)
+		}
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	var tests = []struct {
+		events []Event
+		want   error
+	}{
+		{
+			[]Event{{Type: "dance"}},
+			ErrUnknownEvent,
+		},
+		{
+			[]Event{{Type: EventPlayerJoined, Player: "A"}, {Type: EventPlayerJoined, Player: "A"}},
+			ErrPlayerExists,
+		},
+		{
+			[]Event{
+				{Type: EventPlayerJoined, Player: "A"},
+				{Type: EventPlayerJoined, Player: "B"},
+				{Type: EventPlayerJoined, Player: "C"},
+				{Type: EventPlayerJoined, Player: "D"},
+				{Type: EventPlayerJoined, Player: "E"},
+				{Type: EventRolesAssigned},
+			},
+			ErrMissingAssignment,
+		},
+		{
+			[]Event{{Type: EventOptionEnabled, Option: "dance"}},
+			ErrUnknownOption,
+		},
+		{
+			[]Event{{Type: EventOptionDisabled, Option: "lake"}},
+			ErrOptionNotEnabled,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Replay(test.events)
+		if !errors.Is(err, test.want) {
+			t.Errorf("wanted %v, got %v", test.want, err)
+		}
+	}
+
+	var perr *PhaseError
+	_, err := Replay([]Event{{Type: EventVoteCast, Player: "A", Approve: true}})
+	if !errors.As(err, &perr) {
+		t.Errorf("expected PhaseError, got %v", err)
+	}
+
+	// Options cannot change once the game has started
+	events := playFullGame(t).Events()
+	events = append(events, Event{Type: EventOptionEnabled, Option: "oberon"})
+	if _, err := Replay(events); !errors.As(err, &perr) {
+		t.Errorf("expected PhaseError, got %v", err)
+	}
+}
+
+func TestReplayInvalidAssignment(t *testing.T) {
+	valid := func() *Assignment {
+		return &Assignment{
+			Seats:    []string{"A", "B", "C", "D", "E"},
+			Goods:    []string{"A", "B", "C"},
+			Evils:    []string{"D", "E"},
+			Specials: map[string]string{"merlin": "A", "assassin": "D"},
+			Leader:   "A",
+		}
+	}
+
+	var tests = []struct {
+		name   string
+		change func(*Assignment)
+		want   error
+	}{
+		{"valid", func(*Assignment) {}, nil},
+		{"unknown seat", func(a *Assignment) { a.Seats = []string{"Z"} }, ErrInvalidAssignment},
+		{"repeated seat", func(a *Assignment) { a.Seats[4] = "A" }, ErrInvalidAssignment},
+		{"no evils", func(a *Assignment) { a.Goods, a.Evils = a.Seats, nil }, ErrInvalidAssignment},
+		{"player on both sides", func(a *Assignment) { a.Evils[1] = "C" }, ErrInvalidAssignment},
+		{"missing special", func(a *Assignment) { delete(a.Specials, "assassin") }, ErrInvalidAssignment},
+		{"extra special", func(a *Assignment) { a.Specials["oberon"] = "E" }, ErrInvalidAssignment},
+		{"special on wrong side", func(a *Assignment) { a.Specials["merlin"] = "E" }, ErrInvalidAssignment},
+		{"two specials at once", func(a *Assignment) { a.Specials["assassin"] = "A" }, ErrInvalidAssignment},
+		{"unseated leader", func(a *Assignment) { a.Leader = "Q" }, ErrInvalidAssignment},
+		{"lake without the option", func(a *Assignment) { a.Lake = "B" }, ErrInvalidAssignment},
+	}
+
+	for _, test := range tests {
+		assignment := valid()
+		test.change(assignment)
+
+		var events []Event
+		for _, nick := range []string{"A", "B", "C", "D", "E"} {
+			events = append(events, Event{Type: EventPlayerJoined, Player: nick})
+		}
+		events = append(events, Event{Type: EventRolesAssigned, Assignment: assignment, Salt: newSalt()})
+
+		if _, err := Replay(events); !errors.Is(err, test.want) {
+			t.Errorf("%s: wanted %v, got %v", test.name, test.want, err)
+		}
+	}
+}
+
+func TestOptionsLockedAfterStart(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"mordred"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	av.EnableOption("morganapercival")
+	av.DisableOption("mordred")
+
+	if av.IsOptionEnabled("morganapercival") || !av.IsOptionEnabled("mordred") {
+		t.Errorf("options changed after start: %v", av.OptionsEnabled)
+	}
+}
@@ -0,0 +1,111 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrExcaliburRequired indicates that a party was proposed without
+	// Excalibur while the excalibur option is enabled.
+	ErrExcaliburRequired = errors.New("avalon: the leader must give excalibur to a party member")
+
+	// ErrExcaliburDisabled indicates that Excalibur was given out while the
+	// excalibur option is not enabled.
+	ErrExcaliburDisabled = errors.New("avalon: excalibur is not in this game")
+
+	// ErrExcaliburToLeader indicates that the leader tried to give Excalibur
+	// to themselves.
+	ErrExcaliburToLeader = errors.New("avalon: the leader cannot keep excalibur")
+
+	// ErrNotExcaliburWielder indicates that someone other than the player
+	// holding Excalibur tried to use it.
+	ErrNotExcaliburWielder = errors.New("avalon: only the wielder may use excalibur")
+
+	// ErrExcaliburOnSelf indicates that the wielder tried to use Excalibur on
+	// their own quest card.
+	ErrExcaliburOnSelf = errors.New("avalon: excalibur cannot be used on the wielder's own card")
+)
+
+// ProposePartyWithExcalibur proposes a party exactly like ProposeParty and
+// gives Excalibur to wielder, who must be a member of the party other than the
+// leader. Only valid with the excalibur option enabled.
+func (av *Avalon) ProposePartyWithExcalibur(leader string, party []string, wielder string) error {
+	return av.Propose(leader, PartyProposal{Party: party, Excalibur: wielder, Quest: noTarget})
+}
+
+func (av *Avalon) validateExcalibur(leader string, party []string, wielder string) error {
+	if !av.IsOptionEnabled("excalibur") {
+		if wielder != "" {
+			return ErrExcaliburDisabled
+		}
+		return nil
+	}
+
+	switch {
+	case wielder == "":
+		return ErrExcaliburRequired
+	case !contains(party, wielder):
+		return ErrNotInParty
+	case wielder == leader:
+		return ErrExcaliburToLeader
+	}
+
+	return nil
+}
+
+// UseExcalibur flips the quest card target played, which must belong to
+// another member of the party, and then resolves the quest. It returns the
+// card target originally played, true for success, which only the wielder
+// should be told.
+func (av *Avalon) UseExcalibur(wielder string, target string) (bool, error) {
+	if err := av.expectPhase("use excalibur", PhaseExcalibur); err != nil {
+		return false, err
+	}
+
+	if wielder != av.CurrentExcalibur {
+		return false, ErrNotExcaliburWielder
+	}
+
+	if !contains(av.CurrentProposedParty, target) {
+		return false, ErrNotInParty
+	}
+
+	if target == wielder {
+		return false, ErrExcaliburOnSelf
+	}
+
+	original := av.questCardsPlayed[target]
+	av.record(Event{Type: EventExcaliburUsed, Player: wielder, Target: target})
+	return original, nil
+}
+
+func (av *Avalon) applyExcaliburUsed(ev Event) {
+	if av.questCardsPlayed[ev.Target] {
+		av.questFailsPlayed++
+	} else {
+		av.questFailsPlayed--
+	}
+	av.questCardsPlayed[ev.Target] = !av.questCardsPlayed[ev.Target]
+
+	av.currentRecord().ExcaliburTarget = ev.Target
+	av.resolveQuest()
+}
+
+// DeclineExcalibur lets the wielder resolve the quest without using
+// Excalibur.
+func (av *Avalon) DeclineExcalibur(wielder string) error {
+	if err := av.expectPhase("decline excalibur", PhaseExcalibur); err != nil {
+		return err
+	}
+
+	if wielder != av.CurrentExcalibur {
+		return ErrNotExcaliburWielder
+	}
+
+	av.record(Event{Type: EventExcaliburDeclined, Player: wielder})
+	return nil
+}
+
+func (av *Avalon) applyExcaliburDeclined(ev Event) {
+	av.resolveQuest()
+}
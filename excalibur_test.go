@@ -0,0 +1,144 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newExcaliburAvalon starts a five player excalibur game and sends a party of
+// an evil and a good who is not the leader, with Excalibur given to the good.
+func newExcaliburAvalon(t *testing.T) (av *Avalon, evil string, wielder string) {
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"excalibur"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evil = av.Evils[0]
+	for _, nick := range av.Goods {
+		if nick != av.CurrentLeader {
+			wielder = nick
+			break
+		}
+	}
+
+	if err := av.ProposePartyWithExcalibur(av.CurrentLeader, []string{evil, wielder}, wielder); err != nil {
+		t.Fatalf("unexpected error proposing: %v", err)
+	}
+	voteAll(t, av, true)
+
+	return av, evil, wielder
+}
+
+func TestProposePartyWithExcalibur(t *testing.T) {
+	var tests = []struct {
+		options []string
+		wielder int // index into the party, or -1 for an outsider
+		none    bool
+		want    error
+	}{
+		{nil, 0, true, nil},
+		{nil, 1, false, ErrExcaliburDisabled},
+		{[]string{"excalibur"}, 0, true, ErrExcaliburRequired},
+		{[]string{"excalibur"}, -1, false, ErrNotInParty},
+		{[]string{"excalibur"}, 0, false, ErrExcaliburToLeader},
+		{[]string{"excalibur"}, 1, false, nil},
+	}
+
+	for _, test := range tests {
+		av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, test.options)
+		if err := av.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := av.BeginQuests(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		party := []string{av.CurrentLeader, av.LeftOf(av.CurrentLeader)}
+		var wielder string
+		switch {
+		case test.none:
+		case test.wielder < 0:
+			wielder = av.RightOf(av.CurrentLeader)
+		default:
+			wielder = party[test.wielder]
+		}
+
+		err := av.ProposePartyWithExcalibur(av.CurrentLeader, party, wielder)
+		if err != test.want {
+			t.Errorf("with %v and %q, wanted %v, got %v", test.options, wielder, test.want, err)
+		}
+	}
+}
+
+func TestUseExcalibur(t *testing.T) {
+	av, evil, wielder := newExcaliburAvalon(t)
+	if av.Proposals()[0].Excalibur != wielder {
+		t.Errorf("expected %s recorded as holding excalibur, got %+v", wielder, av.Proposals()[0])
+	}
+
+	if err := av.PlayQuestCard(evil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.PlayQuestCard(wielder, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.Phase != PhaseExcalibur {
+		t.Fatalf("expected %s once every card is played, got %s", PhaseExcalibur, av.Phase)
+	}
+
+	if _, err := av.UseExcalibur(evil, wielder); err != ErrNotExcaliburWielder {
+		t.Errorf("wanted %v, got %v", ErrNotExcaliburWielder, err)
+	}
+	if _, err := av.UseExcalibur(wielder, wielder); err != ErrExcaliburOnSelf {
+		t.Errorf("wanted %v, got %v", ErrExcaliburOnSelf, err)
+	}
+
+	original, err := av.UseExcalibur(wielder, evil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if original {
+		t.Errorf("expected %s to learn that %s played fail", wielder, evil)
+	}
+
+	record := av.QuestHistory[0]
+	if !record.Success || record.Fails != 0 || record.Excalibur != wielder || record.ExcaliburTarget != evil {
+		t.Errorf("expected the flipped fail to let the quest succeed, got %+v", record)
+	}
+	if av.Phase != PhaseProposing || av.CurrentExcalibur != "" {
+		t.Errorf("expected %s without excalibur, got %s with %q", PhaseProposing, av.Phase, av.CurrentExcalibur)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
+
+func TestDeclineExcalibur(t *testing.T) {
+	av, evil, wielder := newExcaliburAvalon(t)
+	if err := av.PlayQuestCard(evil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.PlayQuestCard(wielder, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := av.DeclineExcalibur(evil); err != ErrNotExcaliburWielder {
+		t.Errorf("wanted %v, got %v", ErrNotExcaliburWielder, err)
+	}
+	if err := av.DeclineExcalibur(wielder); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	record := av.QuestHistory[0]
+	if record.Success || record.Fails != 1 || record.ExcaliburTarget != "" {
+		t.Errorf("expected the quest to fail untouched, got %+v", record)
+	}
+}
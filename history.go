@@ -0,0 +1,76 @@
+package avalon
+
+// QuestRecord is the full history of a single quest: every party proposed for
+// it and how each was voted on, the party that went and how it went. Records
+// are kept in the order quests were attempted, which with the targeting option
+// need not be the order of Quest.
+type QuestRecord struct {
+	Quest     int        `json:"quest"`
+	Proposals []Proposal `json:"proposals"`
+
+	// Only set once the quest has been resolved. Fails is the number of fail
+	// cards played, without saying who played them, after Excalibur was
+	// used on ExcaliburTarget, if it was.
+	Party           []string `json:"party,omitempty"`
+	Excalibur       string   `json:"excalibur,omitempty"`
+	ExcaliburTarget string   `json:"excalibur_target,omitempty"`
+	Fails           int      `json:"fails"`
+	Success         bool     `json:"success"`
+	Resolved        bool     `json:"resolved"`
+
+	// How many chief cards the party played, with the hunter option.
+	ChiefCards int `json:"chief_cards,omitempty"`
+
+	// The party member put In the Spotlight, whose card was played face up.
+	Spotlight        string `json:"spotlight,omitempty"`
+	SpotlightSuccess bool   `json:"spotlight_success,omitempty"`
+}
+
+// currentRecord returns the record for the current quest, starting one if this
+// is the quest's first proposal.
+func (av *Avalon) currentRecord() *QuestRecord {
+	for len(av.QuestHistory) <= av.CurrentQuest {
+		av.QuestHistory = append(av.QuestHistory, QuestRecord{Quest: av.targetQuest()})
+	}
+
+	return &av.QuestHistory[av.CurrentQuest]
+}
+
+// QuestSuccesses returns whether each resolved quest succeeded, in order.
+func (av *Avalon) QuestSuccesses() []bool {
+	var successes []bool
+	for _, record := range av.QuestHistory {
+		if record.Resolved {
+			successes = append(successes, record.Success)
+		}
+	}
+
+	return successes
+}
+
+// Proposals returns every party proposed during the game, in order.
+func (av *Avalon) Proposals() []Proposal {
+	var proposals []Proposal
+	for _, record := range av.QuestHistory {
+		proposals = append(proposals, record.Proposals...)
+	}
+
+	return proposals
+}
+
+// NumSuccesses returns the number of quests that have succeeded so far.
+func (av *Avalon) NumSuccesses() int {
+	var count int
+	for _, success := range av.QuestSuccesses() {
+		if success {
+			count++
+		}
+	}
+
+	return count
+}
+
+// NumFails returns the number of quests that have failed so far.
+func (av *Avalon) NumFails() int {
+	return len(av.QuestSuccesses()) - av.NumSuccesses()
+}
@@ -0,0 +1,128 @@
+package avalon
+
+import (
+	"math"
+)
+
+// defaultDamping is how much less likely a player is to get a role again for
+// every consecutive recent game they have already had it.
+const defaultDamping = 0.5
+
+// HistoryStrategy assigns roles randomly but makes players less likely to
+// repeat a streak. History maps each player to the roles they had in past
+// games, oldest first, using a special character's name or "good" or "evil".
+// A player who has been evil for the last k games is Damping^k times as
+// likely as usual to be picked as evil again, and the same goes for each
+// special character. Weights never reach zero, so no assignment is ever
+// certain.
+type HistoryStrategy struct {
+	History map[string][]string
+	Damping float64
+}
+
+// AssignRoles implements Strategy.
+func (hs HistoryStrategy) AssignRoles(av *Avalon, random Random) error {
+	evils := hs.pick(av.Players, av.NumEvils(), random, isEvilRole)
+	for _, nick := range av.Players {
+		if contains(evils, nick) {
+			av.Evils = append(av.Evils, nick)
+		} else {
+			av.Goods = append(av.Goods, nick)
+		}
+	}
+
+	for _, role := range roleCards(av) {
+		if isPlainRole(role) {
+			continue
+		}
+
+		side := av.Goods
+		if isEvilRole(role) {
+			side = av.Evils
+		}
+
+		var candidates []string
+		for _, nick := range side {
+			if av.RoleOf(nick) == "" {
+				candidates = append(candidates, nick)
+			}
+		}
+
+		special := role
+		picked := hs.pick(candidates, 1, random, func(r string) bool { return r == special })
+		av.Specials[role] = picked[0]
+	}
+
+	return nil
+}
+
+// pick draws n players without replacement, each weighted down by how many of
+// their most recent games had a role that matches.
+func (hs HistoryStrategy) pick(players []string, n int, random Random, matches func(role string) bool) []string {
+	damping := hs.Damping
+	if damping <= 0 || damping > 1 {
+		damping = defaultDamping
+	}
+
+	remaining := copyStrings(players)
+	weights := make([]float64, len(remaining))
+	for i, nick := range remaining {
+		weights[i] = math.Pow(damping, float64(streak(hs.History[nick], matches)))
+	}
+
+	var picked []string
+	for len(picked) < n && len(remaining) > 0 {
+		i := weightedIndex(weights, random)
+		picked = append(picked, remaining[i])
+		remaining = deleteAt(remaining, i)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+
+	return picked
+}
+
+// streak returns how many of the most recent roles in history match.
+func streak(history []string, matches func(role string) bool) int {
+	var count int
+	for i := len(history) - 1; i >= 0 && matches(history[i]); i-- {
+		count++
+	}
+
+	return count
+}
+
+// weightResolution is the granularity used to turn Random's integers into a
+// point on the total weight.
+const weightResolution = 1 << 30
+
+// weightedIndex returns an index into weights chosen with probability
+// proportional to its weight.
+func weightedIndex(weights []float64, random Random) int {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	target := float64(random.Intn(weightResolution)) / weightResolution * total
+	for i, w := range weights {
+		if target < w {
+			return i
+		}
+		target -= w
+	}
+
+	return len(weights) - 1
+}
+
+// RoleHistory builds the History for a HistoryStrategy from stored games,
+// given oldest first.
+func RoleHistory(games []*Avalon) map[string][]string {
+	history := make(map[string][]string)
+	for _, game := range games {
+		for _, nick := range game.Players {
+			history[nick] = append(history[nick], game.RoleName(nick))
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,102 @@
+package avalon
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestStreak(t *testing.T) {
+	var tests = []struct {
+		history []string
+		want    int
+	}{
+		{nil, 0},
+		{[]string{"good"}, 0},
+		{[]string{"evil", "good"}, 0},
+		{[]string{"good", "evil", "assassin"}, 2},
+		{[]string{"evil", "mordred", "evil"}, 3},
+	}
+
+	for _, test := range tests {
+		if n := streak(test.history, isEvilRole); n != test.want {
+			t.Errorf("wanted streak %d for %v, got %d", test.want, test.history, n)
+		}
+	}
+}
+
+func TestHistoryStrategy(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		avalon := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"morganapercival", "lake"})
+		avalon.SetStrategy(HistoryStrategy{History: map[string][]string{
+			"A": {"evil", "assassin"},
+			"B": {"merlin", "merlin"},
+		}})
+
+		if err := avalon.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		runRequirementTests(t, avalon)
+	}
+}
+
+// Players on a streak are less likely to continue it, but never ruled out.
+func TestHistoryStrategyBias(t *testing.T) {
+	const runs = 5000
+	players := []string{"A", "B", "C", "D", "E", "F", "G"}
+
+	avalon := NewAvalon()
+	avalon.Players = players
+	strategy := HistoryStrategy{History: map[string][]string{
+		"A": {"evil", "evil", "evil"},
+		"B": {"good", "merlin", "merlin"},
+	}}
+
+	random := rand.New(rand.NewSource(1))
+	stats := SimulateRoles(avalon, strategy, random, runs)
+
+	if rate := stats.EvilRate["A"]; rate >= stats.UniformEvilRate/2 || rate == 0 {
+		t.Errorf("expected A's evil rate well under %.2f but above zero, got %.3f", stats.UniformEvilRate, rate)
+	}
+	if rate := stats.RoleRate["merlin"]["B"]; rate >= stats.UniformRoleRate["merlin"]/2 || rate == 0 {
+		t.Errorf("expected B's merlin rate well under %.2f but above zero, got %.3f", stats.UniformRoleRate["merlin"], rate)
+	}
+	if bias := stats.EvilBias(players); bias < 0.1 {
+		t.Errorf("expected a measurable evil bias, got %.3f", bias)
+	}
+
+	// Without any history the strategy is close to uniform
+	stats = SimulateRoles(avalon, HistoryStrategy{}, random, runs)
+	if bias := stats.EvilBias(players); bias > 0.05 {
+		t.Errorf("expected no evil bias without history, got %.3f", bias)
+	}
+	if bias := stats.RoleBias("merlin", players); bias > 0.05 {
+		t.Errorf("expected no merlin bias without history, got %.3f", bias)
+	}
+}
+
+func TestRoleHistory(t *testing.T) {
+	first := NewAvalon()
+	first.Players = []string{"A", "B", "C"}
+	first.Goods = []string{"A", "B"}
+	first.Evils = []string{"C"}
+	first.Specials = map[string]string{"merlin": "A", "assassin": "C"}
+
+	second := NewAvalon()
+	second.Players = []string{"A", "C", "D"}
+	second.Goods = []string{"C", "D"}
+	second.Evils = []string{"A"}
+	second.Specials = map[string]string{"merlin": "D", "assassin": "A"}
+
+	want := map[string][]string{
+		"A": {"merlin", "assassin"},
+		"B": {"good"},
+		"C": {"assassin", "good"},
+		"D": {"merlin"},
+	}
+
+	history := RoleHistory([]*Avalon{first, second})
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("wanted %v, got %v", want, history)
+	}
+}
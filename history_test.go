@@ -0,0 +1,57 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuestHistory(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Quest 1: one rejection, then an approved party that succeeds
+	firstLeader := av.CurrentLeader
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, false)
+	playQuest(t, av, true)
+
+	// Quest 2: fails straight away
+	playQuest(t, av, false)
+
+	if len(av.QuestHistory) != 2 {
+		t.Fatalf("expected 2 quests recorded, got %d", len(av.QuestHistory))
+	}
+
+	first := av.QuestHistory[0]
+	if first.Quest != 0 || len(first.Proposals) != 2 || !first.Resolved || !first.Success || first.Fails != 0 {
+		t.Errorf("unexpected record for quest 1: %+v", first)
+	}
+	if first.Proposals[0].Approved || !first.Proposals[1].Approved {
+		t.Errorf("expected rejection then approval, got %+v", first.Proposals)
+	}
+	if first.Proposals[0].Leader != firstLeader || first.Proposals[1].Leader != av.LeftOf(firstLeader) {
+		t.Errorf("expected proposals led by %s then %s, got %+v", firstLeader, av.LeftOf(firstLeader), first.Proposals)
+	}
+	if !reflect.DeepEqual(first.Party, first.Proposals[1].Party) {
+		t.Errorf("expected approved party %v to go on quest, got %v", first.Proposals[1].Party, first.Party)
+	}
+
+	second := av.QuestHistory[1]
+	if second.Quest != 1 || len(second.Proposals) != 1 || !second.Resolved || second.Success || second.Fails != 1 {
+		t.Errorf("unexpected record for quest 2: %+v", second)
+	}
+
+	if successes := av.QuestSuccesses(); !reflect.DeepEqual(successes, []bool{true, false}) {
+		t.Errorf("expected successes [true false], got %v", successes)
+	}
+	if n := len(av.Proposals()); n != 3 {
+		t.Errorf("expected 3 proposals in total, got %d", n)
+	}
+}
@@ -0,0 +1,140 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotInvestigator indicates that someone other than the leader whose
+	// party played chief cards tried to investigate.
+	ErrNotInvestigator = errors.New("avalon: only the leader of that quest may investigate")
+
+	// ErrNotHunter indicates that someone other than a hunter who may accuse
+	// right now tried to accuse a chief.
+	ErrNotHunter = errors.New("avalon: only the hunter may accuse")
+)
+
+// With the hunter option, from The Resistance: Hidden Agenda, each side has a
+// Chief and a Hunter. Once either side has three quests, the game is not over:
+// the Hunter of the side that lost them names who they think the other side's
+// Chief is, and their side wins if they are right. A Hunter may instead
+// accuse early, before a party is proposed, but their side loses if they are
+// wrong.
+//
+// Chiefs and Dummy Agents play chief cards, so everyone can see how many went
+// on a quest. After a quest with chief cards, the leader whose party went on
+// it investigates one other member of the party and privately learns whether
+// they are a Chief.
+
+// Investigation is the public record of a leader investigating a party member
+// after a quest with chief cards. What they learned is private.
+type Investigation struct {
+	Quest  int    `json:"quest"`
+	Leader string `json:"leader"`
+	Target string `json:"target"`
+}
+
+// numChiefCards returns how many chief cards the given party played.
+func (av *Avalon) numChiefCards(party []string) int {
+	var count int
+	for _, nick := range party {
+		if role, _ := LookupRole(av.RoleName(nick)); role.PlaysChiefCards {
+			count++
+		}
+	}
+
+	return count
+}
+
+// isChief returns whether the given player is their side's Chief.
+func (av *Avalon) isChief(nick string) bool {
+	role, _ := LookupRole(av.RoleName(nick))
+	return role.Chief
+}
+
+// isHunter returns whether the given player is their side's Hunter.
+func (av *Avalon) isHunter(nick string) bool {
+	role, _ := LookupRole(av.RoleName(nick))
+	return role.Hunter
+}
+
+// Investigate has the leader of the last quest investigate a member of its
+// party and returns whether the target is a Chief, for the leader's eyes only.
+func (av *Avalon) Investigate(leader string, target string) (bool, error) {
+	if err := av.expectPhase("investigate", PhaseInvestigation); err != nil {
+		return false, err
+	}
+
+	if leader != av.CurrentInvestigator {
+		return false, ErrNotInvestigator
+	}
+
+	record := av.QuestHistory[av.CurrentQuest-1]
+	if target == leader || !contains(record.Party, target) {
+		return false, ErrNotInParty
+	}
+
+	av.record(Event{Type: EventChiefInvestigated, Player: leader, Target: target})
+	return av.isChief(target), nil
+}
+
+func (av *Avalon) applyChiefInvestigated(ev Event) {
+	av.Investigations = append(av.Investigations, Investigation{
+		Quest:  av.lastQuest(),
+		Leader: ev.Player,
+		Target: ev.Target,
+	})
+	av.CurrentInvestigator = ""
+	av.continueQuests()
+}
+
+// Accuse has a hunter name who they think the other side's Chief is, which
+// ends the game. Once a side has three quests only the other side's Hunter
+// may accuse. Before then, either Hunter may accuse early while a party is
+// being proposed.
+func (av *Avalon) Accuse(hunter string, target string) error {
+	if err := av.expectPhase("accuse a chief", PhaseProposing, PhaseHunt); err != nil {
+		return err
+	}
+
+	if !av.IsOptionEnabled("hunter") || !av.isHunter(hunter) {
+		return ErrNotHunter
+	}
+
+	if av.Phase == PhaseHunt && av.LoyaltyOf(hunter) != av.huntingSide() {
+		return ErrNotHunter
+	}
+
+	if !av.PlayerExists(target) {
+		return ErrPlayerNotFound
+	}
+
+	av.record(Event{Type: EventChiefAccused, Player: hunter, Target: target})
+	return nil
+}
+
+func (av *Avalon) applyChiefAccused(ev Event) {
+	av.Accuser = ev.Player
+	av.AccusationTarget = ev.Target
+	av.Phase = PhaseGameOver
+}
+
+// huntingSide returns the side whose Hunter accuses once the other side has
+// three quests.
+func (av *Avalon) huntingSide() Loyalty {
+	if av.NumSuccesses() >= 3 {
+		return LoyaltyEvil
+	}
+
+	return LoyaltyGood
+}
+
+// accusationResult returns who won by the hunter's accusation.
+func (av *Avalon) accusationResult() Result {
+	side := av.LoyaltyOf(av.Accuser)
+	if av.isChief(av.AccusationTarget) && av.LoyaltyOf(av.AccusationTarget) != side {
+		return Result{side, ReasonChiefFound}
+	}
+
+	return Result{side.opposite(), ReasonChiefEscaped}
+}
@@ -0,0 +1,164 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newHunterAvalon starts a five player hunter game with a Dummy Agent, so
+// every player has a role.
+func newHunterAvalon(t *testing.T) *Avalon {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"resistance", "hunter", "dummyagent"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return av
+}
+
+// playHunterQuest plays a successful quest with a valid party, then has the
+// leader investigate another party member if chief cards were played.
+func playHunterQuest(t *testing.T, av *Avalon) {
+	leader := av.CurrentLeader
+	party := validParty(av)
+	playQuest(t, av, true)
+
+	if av.Phase != PhaseInvestigation {
+		return
+	}
+	for _, nick := range party {
+		if nick != leader {
+			if _, err := av.Investigate(leader, nick); err != nil {
+				t.Fatalf("unexpected error investigating: %v", err)
+			}
+			return
+		}
+	}
+}
+
+func TestInvestigate(t *testing.T) {
+	av := newHunterAvalon(t)
+	leader := av.CurrentLeader
+	chief := av.Specials["resistancechief"]
+	party := []string{chief, av.Specials["spyhunter"]}
+
+	if err := av.ProposeParty(leader, party); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	for _, nick := range party {
+		if err := av.PlayQuestCard(nick, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if record := av.QuestHistory[0]; record.ChiefCards != 1 {
+		t.Errorf("expected 1 chief card, got %d", record.ChiefCards)
+	}
+	if av.Phase != PhaseInvestigation || av.CurrentInvestigator != leader {
+		t.Fatalf("expected %s to investigate, got %s in %s", leader, av.CurrentInvestigator, av.Phase)
+	}
+
+	var outsider string
+	for _, nick := range av.Players {
+		if !contains(party, nick) && nick != leader {
+			outsider = nick
+		}
+	}
+
+	var tests = []struct {
+		leader string
+		target string
+		want   error
+	}{
+		{av.LeftOf(leader), chief, ErrNotInvestigator},
+		{leader, outsider, ErrNotInParty},
+		{leader, leader, ErrNotInParty},
+	}
+
+	for _, test := range tests {
+		if _, err := av.Investigate(test.leader, test.target); err != test.want {
+			t.Errorf("%s investigating %s, wanted %v, got %v", test.leader, test.target, test.want, err)
+		}
+	}
+
+	// The leader may have sent themselves with the chief
+	target := chief
+	if target == leader {
+		target = party[1]
+	}
+	isChief, err := av.Investigate(leader, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isChief != (target == chief) {
+		t.Errorf("expected investigating %s to say %t, got %t", target, target == chief, isChief)
+	}
+	if av.Phase != PhaseProposing || len(av.Investigations) != 1 {
+		t.Errorf("expected the next proposal after one investigation, got %s and %v", av.Phase, av.Investigations)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
+
+func TestAccuse(t *testing.T) {
+	// An early accusation that misses loses the game for the accuser
+	av := newHunterAvalon(t)
+	if err := av.Accuse(av.Specials["resistancechief"], av.Specials["spychief"]); err != ErrNotHunter {
+		t.Errorf("wanted %v, got %v", ErrNotHunter, err)
+	}
+	if err := av.Accuse(av.Specials["spyhunter"], "Z"); err != ErrPlayerNotFound {
+		t.Errorf("wanted %v, got %v", ErrPlayerNotFound, err)
+	}
+	if err := av.Accuse(av.Specials["spyhunter"], av.Specials["dummyagent"]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := av.Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Result{LoyaltyGood, ReasonChiefEscaped}); result != want {
+		t.Errorf("wanted %s, got %s", want, result)
+	}
+
+	// Once the resistance has three quests, only the spies may hunt
+	av = newHunterAvalon(t)
+	for i := 0; i < 3; i++ {
+		playHunterQuest(t, av)
+	}
+	if av.Phase != PhaseHunt {
+		t.Fatalf("expected %s after three successes, got %s", PhaseHunt, av.Phase)
+	}
+	if err := av.Accuse(av.Specials["resistancehunter"], av.Specials["spychief"]); err != ErrNotHunter {
+		t.Errorf("wanted %v, got %v", ErrNotHunter, err)
+	}
+	if err := av.Accuse(av.Specials["spyhunter"], av.Specials["resistancechief"]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err = av.Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Result{LoyaltyEvil, ReasonChiefFound}); result != want {
+		t.Errorf("wanted %s, got %s", want, result)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
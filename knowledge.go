@@ -0,0 +1,148 @@
+package avalon
+
+import (
+	"sort"
+)
+
+// View is everything a single player learns about the other players at night.
+type View struct {
+	Nick    string
+	Loyalty Loyalty
+	Role    string
+
+	// Players this player knows to be evil.
+	KnownEvils []string
+
+	// Players who might be each special character this player sees, in no
+	// particular order. Percival, for example, sees Merlin and Morgana under
+	// "merlin" without knowing which is which.
+	Candidates map[string][]string
+
+	// Loyalties this player has been shown by investigating other players,
+	// like the Cleric with the first leader or with the Lady of the Lake.
+	Investigated map[string]Loyalty
+}
+
+// RoleOf returns the special character assigned to the given player, or "" if
+// they are a regular good or evil.
+func (av *Avalon) RoleOf(nick string) string {
+	for special, assigned := range av.Specials {
+		if assigned == nick {
+			return special
+		}
+	}
+
+	return ""
+}
+
+// RoleName returns the given player's special character, or "good" or "evil"
+// if they do not have one. This is the form HistoryStrategy expects.
+func (av *Avalon) RoleName(nick string) string {
+	if role := av.RoleOf(nick); role != "" {
+		return role
+	}
+
+	if av.dealtLoyalty(nick) == LoyaltyEvil {
+		return roleEvil
+	}
+
+	return roleGood
+}
+
+// KnowledgeFor computes exactly what the given player knows once roles have
+// been assigned, following what each role Sees and is HiddenFrom in the role
+// registry. In the base game:
+//
+//   - Merlin sees every evil except Mordred.
+//   - Percival sees Merlin and Morgana, but not which is which.
+//   - Evils see each other, except Oberon.
+//   - Oberon and regular goods see nobody.
+//   - The Cleric investigates the first leader, once they have answered if
+//     they may lie.
+//   - Lady of the Lake holders see what each player they looked at showed.
+//
+// Players are shown the side they were dealt, even if the Lancelots have
+// since switched.
+func (av *Avalon) KnowledgeFor(nick string) View {
+	view := View{
+		Nick:    nick,
+		Loyalty: av.dealtLoyalty(nick),
+		Role:    av.RoleOf(nick),
+	}
+
+	if view.Loyalty == LoyaltyUnknown {
+		return view
+	}
+
+	viewer, _ := LookupRole(av.RoleName(nick))
+	viewer = viewer.in(av.AvalonConfig)
+	for _, other := range av.Players {
+		if other == nick {
+			continue
+		}
+
+		role, _ := LookupRole(av.RoleName(other))
+		switch seen := role.seenBy(viewer); seen {
+		case "":
+		case roleEvil:
+			view.KnownEvils = append(view.KnownEvils, other)
+		default:
+			if view.Candidates == nil {
+				view.Candidates = make(map[string][]string)
+			}
+			view.Candidates[seen] = append(view.Candidates[seen], other)
+		}
+	}
+
+	if viewer.SeesFirstLeader && av.FirstLeader != "" && av.FirstLeader != nick {
+		if shown := av.shownToCleric(); shown != LoyaltyUnknown {
+			view.Investigated = map[string]Loyalty{av.FirstLeader: shown}
+		}
+	}
+	for _, use := range av.LakeUses {
+		if use.Holder != nick || use.Shown == LoyaltyUnknown {
+			continue
+		}
+		if view.Investigated == nil {
+			view.Investigated = make(map[string]Loyalty)
+		}
+		view.Investigated[use.Target] = use.Shown
+	}
+
+	sort.Strings(view.KnownEvils)
+	for _, candidates := range view.Candidates {
+		sort.Strings(candidates)
+	}
+
+	return view
+}
+
+// investigate returns the loyalty the given player shows when investigated by
+// the Lady of the Lake or the Cleric. That is their current loyalty unless
+// their role must lie about it, like the Troublemaker, or LoyaltyUnknown if
+// they choose what to show, like the Trickster.
+func (av *Avalon) investigate(nick string) Loyalty {
+	role, _ := LookupRole(av.RoleName(nick))
+	if role.MayLie {
+		return LoyaltyUnknown
+	}
+	if role.InvestigatedAs != LoyaltyUnknown {
+		return role.InvestigatedAs
+	}
+
+	return av.LoyaltyOf(nick)
+}
+
+// Revealed returns the special character of every player whose role has been
+// revealed to everyone, like the Revealer after the second failed quest.
+func (av *Avalon) Revealed() map[string]string {
+	revealed := make(map[string]string)
+	for special, nick := range av.Specials {
+		role, _ := LookupRole(special)
+		if role.RevealedAfterFails > 0 && av.NumFails() >= role.RevealedAfterFails {
+			revealed[nick] = special
+		}
+	}
+
+	return revealed
+}
@@ -0,0 +1,145 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newKnowledgeAvalon() *Avalon {
+	avalon := NewAvalon()
+	avalon.Players = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+	avalon.Goods = []string{"A", "B", "C", "D", "E", "F"}
+	avalon.Evils = []string{"G", "H", "I", "J"}
+	avalon.EnableMany([]string{"mordred", "morganapercival", "oberon"})
+	avalon.Specials = map[string]string{
+		"merlin":   "A",
+		"percival": "B",
+		"assassin": "G",
+		"mordred":  "H",
+		"morgana":  "I",
+		"oberon":   "J",
+	}
+
+	return avalon
+}
+
+func TestKnowledgeFor(t *testing.T) {
+	var tests = []struct {
+		nick       string
+		loyalty    Loyalty
+		role       string
+		evils      []string
+		candidates []string
+	}{
+		// Merlin sees Oberon but not Mordred
+		{"A", LoyaltyGood, "merlin", []string{"G", "I", "J"}, nil},
+		{"B", LoyaltyGood, "percival", nil, []string{"A", "I"}},
+		{"C", LoyaltyGood, "", nil, nil},
+		// Evils see each other but not Oberon
+		{"G", LoyaltyEvil, "assassin", []string{"H", "I"}, nil},
+		{"H", LoyaltyEvil, "mordred", []string{"G", "I"}, nil},
+		{"I", LoyaltyEvil, "morgana", []string{"G", "H"}, nil},
+		{"J", LoyaltyEvil, "oberon", nil, nil},
+		{"Z", LoyaltyUnknown, "", nil, nil},
+	}
+
+	for _, test := range tests {
+		avalon := newKnowledgeAvalon()
+
+		view := avalon.KnowledgeFor(test.nick)
+		if view.Nick != test.nick || view.Loyalty != test.loyalty || view.Role != test.role {
+			t.Errorf("wanted %s as %s %q, got %+v", test.nick, test.loyalty, test.role, view)
+		}
+		if len(view.KnownEvils) != 0 || len(test.evils) != 0 {
+			if !reflect.DeepEqual(view.KnownEvils, test.evils) {
+				t.Errorf("wanted %s to see evils %v, got %v", test.nick, test.evils, view.KnownEvils)
+			}
+		}
+		if !reflect.DeepEqual(view.Candidates["merlin"], test.candidates) {
+			t.Errorf("wanted %s to see candidates %v, got %v", test.nick, test.candidates, view.Candidates)
+		}
+	}
+}
+
+// Merlin must never see Mordred, no matter how roles were assigned.
+func TestKnowledgeForAssigned(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E", "F", "G"}
+		avalon.EnableMany([]string{"mordred", "morganapercival"})
+		if err := NewAssigner(avalon, nil).Assign(); err != nil {
+			t.Fatalf("unexpected error assigning: %v", err)
+		}
+
+		merlin := avalon.KnowledgeFor(avalon.Specials["merlin"])
+		if contains(merlin.KnownEvils, avalon.Specials["mordred"]) {
+			t.Fatalf("merlin saw mordred: %v, %v", merlin.KnownEvils, avalon.Specials)
+		}
+		if len(merlin.KnownEvils) != avalon.NumEvils()-1 {
+			t.Errorf("expected merlin to see %d evils, got %v", avalon.NumEvils()-1, merlin.KnownEvils)
+		}
+	}
+}
+
+func TestBigBoxKnowledge(t *testing.T) {
+	newBigBoxAvalon := func(firstLeader string) *Avalon {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
+		avalon.Goods = []string{"A", "B", "C", "D", "E", "F"}
+		avalon.Evils = []string{"G", "H", "I", "J"}
+		avalon.EnableMany([]string{"cleric", "troublemaker", "untrustworthyservant", "trickster", "revealer"})
+		avalon.Specials = map[string]string{
+			"merlin":               "A",
+			"cleric":               "B",
+			"troublemaker":         "C",
+			"untrustworthyservant": "D",
+			"assassin":             "G",
+			"trickster":            "H",
+			"revealer":             "I",
+		}
+		avalon.FirstLeader = firstLeader
+
+		return avalon
+	}
+
+	var tests = []struct {
+		firstLeader string
+		want        Loyalty
+	}{
+		{"E", LoyaltyGood},
+		{"J", LoyaltyEvil},
+		{"C", LoyaltyEvil},
+		{"H", LoyaltyUnknown},
+	}
+
+	for _, test := range tests {
+		view := newBigBoxAvalon(test.firstLeader).KnowledgeFor("B")
+		if seen := view.Investigated[test.firstLeader]; seen != test.want {
+			t.Errorf("expected the cleric to see %s as %s, got %s", test.firstLeader, test.want, seen)
+		}
+	}
+
+	avalon := newBigBoxAvalon("A")
+	if view := avalon.KnowledgeFor("B"); view.Investigated["A"] != LoyaltyGood {
+		t.Errorf("expected the cleric to see Merlin as good, got %v", view.Investigated)
+	}
+	if view := avalon.KnowledgeFor("A"); !reflect.DeepEqual(view.KnownEvils, []string{"D", "G", "H", "I", "J"}) {
+		t.Errorf("expected Merlin to see the servant as evil, got %v", view.KnownEvils)
+	}
+	if view := avalon.KnowledgeFor("D"); !reflect.DeepEqual(view.Candidates["assassin"], []string{"G"}) {
+		t.Errorf("expected the servant to know the assassin, got %v", view.Candidates)
+	}
+	if view := avalon.KnowledgeFor("G"); !reflect.DeepEqual(view.KnownEvils, []string{"H", "I", "J"}) {
+		t.Errorf("expected the assassin not to see the servant, got %v", view.KnownEvils)
+	}
+
+	for i, success := range []bool{false, true, false} {
+		if revealed := avalon.Revealed(); len(revealed) != 0 {
+			t.Errorf("expected nobody revealed after %d quests, got %v", i, revealed)
+		}
+		avalon.QuestHistory = append(avalon.QuestHistory, QuestRecord{Quest: i, Success: success, Resolved: true})
+	}
+	if revealed := avalon.Revealed(); !reflect.DeepEqual(revealed, map[string]string{"I": "revealer"}) {
+		t.Errorf("expected the revealer revealed after two fails, got %v", revealed)
+	}
+}
@@ -0,0 +1,129 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotLakeHolder indicates that someone other than the current holder
+	// tried to use the Lady of the Lake.
+	ErrNotLakeHolder = errors.New("avalon: only the holder may use the lady of the lake")
+
+	// ErrPreviousLakeHolder indicates that the Lady of the Lake was used on a
+	// player who has already held it.
+	ErrPreviousLakeHolder = errors.New("avalon: player has already held the lady of the lake")
+
+	// ErrNoLakeToClaim indicates that a claim was made about a Lady of the
+	// Lake use that does not exist, belongs to someone else or has already
+	// been claimed.
+	ErrNoLakeToClaim = errors.New("avalon: there is no lady of the lake result to claim")
+)
+
+// LakeUse is the record of a single use of the Lady of the Lake: who held it,
+// who they looked at, what they were shown and what they publicly claimed to
+// have seen. Shown is for the holder's eyes only and stays LoyaltyUnknown
+// while a target who may lie is choosing.
+type LakeUse struct {
+	Quest  int     `json:"quest"`
+	Holder string  `json:"holder"`
+	Target string  `json:"target"`
+	Shown  Loyalty `json:"shown,omitempty"`
+	Claim  Loyalty `json:"claim"`
+}
+
+// LakeHolders returns every player who has held the Lady of the Lake in the
+// order they held it, ending with the current holder.
+func (av *Avalon) LakeHolders() []string {
+	if len(av.LakeUses) == 0 {
+		if av.CurrentLake == "" {
+			return nil
+		}
+		return []string{av.CurrentLake}
+	}
+
+	holders := []string{av.LakeUses[0].Holder}
+	for _, use := range av.LakeUses {
+		holders = append(holders, use.Target)
+	}
+
+	return holders
+}
+
+// UseLake lets the current holder of the Lady of the Lake investigate the
+// target's loyalty, which is returned for the holder's eyes only. The token
+// passes to the target, who may not have held it before. If the target may
+// lie, like the Trickster, LoyaltyUnknown is returned and they choose what
+// the holder sees with AnswerInvestigation.
+func (av *Avalon) UseLake(holder string, target string) (Loyalty, error) {
+	if err := av.expectPhase("use the lady of the lake", PhaseLadyOfTheLake); err != nil {
+		return LoyaltyUnknown, err
+	}
+
+	if av.PendingAnswer != "" {
+		return LoyaltyUnknown, ErrAnswerPending
+	}
+
+	if holder != av.CurrentLake {
+		return LoyaltyUnknown, ErrNotLakeHolder
+	}
+
+	if !av.PlayerExists(target) {
+		return LoyaltyUnknown, ErrPlayerNotFound
+	}
+
+	if contains(av.LakeHolders(), target) {
+		return LoyaltyUnknown, ErrPreviousLakeHolder
+	}
+
+	// Quest 3's Lancelot card is flipped once the Lady has been used
+	loyalty := av.investigate(target)
+	av.record(Event{Type: EventLakeUsed, Player: holder, Target: target})
+	return loyalty, nil
+}
+
+func (av *Avalon) applyLakeUsed(ev Event) {
+	av.LakeUses = append(av.LakeUses, LakeUse{
+		Quest:  av.lastQuest(),
+		Holder: ev.Player,
+		Target: ev.Target,
+		Shown:  av.investigate(ev.Target),
+	})
+	av.CurrentLake = ev.Target
+	if av.mayLie(ev.Target) {
+		av.PendingAnswer = ev.Target
+		return
+	}
+
+	av.startQuest()
+}
+
+// ClaimLake records what the previous holder publicly claims to have seen with
+// the most recent use of the Lady of the Lake. The claim must be good or evil
+// but need not be true, can only be made once and only while the game is on.
+func (av *Avalon) ClaimLake(holder string, claim Loyalty) error {
+	if err := av.expectPhase("claim a lady of the lake result",
+		PhaseProposing, PhaseVoting, PhaseQuesting, PhaseExcalibur, PhaseInvestigation,
+		PhaseLadyOfTheLake, PhaseAssassination, PhaseHunt); err != nil {
+		return err
+	}
+
+	if claim != LoyaltyGood && claim != LoyaltyEvil {
+		return ErrInvalidAnswer
+	}
+
+	if len(av.LakeUses) == 0 {
+		return ErrNoLakeToClaim
+	}
+
+	last := av.LakeUses[len(av.LakeUses)-1]
+	if last.Holder != holder || last.Claim != LoyaltyUnknown {
+		return ErrNoLakeToClaim
+	}
+
+	av.record(Event{Type: EventLakeClaimed, Player: holder, Claim: claim})
+	return nil
+}
+
+func (av *Avalon) applyLakeClaimed(ev Event) {
+	av.LakeUses[len(av.LakeUses)-1].Claim = ev.Claim
+}
@@ -0,0 +1,131 @@
+package avalon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newLakeAvalon() *Avalon {
+	avalon := NewAvalon()
+	avalon.Players = []string{"A", "B", "C", "D", "E", "F", "G"}
+	avalon.Goods = []string{"A", "B", "C", "D"}
+	avalon.Evils = []string{"E", "F", "G"}
+	avalon.EnableOption("lake")
+	avalon.CurrentLake = "A"
+	avalon.CurrentQuest = 2
+	avalon.QuestHistory = []QuestRecord{{Quest: 0, Resolved: true}, {Quest: 1, Resolved: true}}
+	avalon.Phase = PhaseLadyOfTheLake
+
+	return avalon
+}
+
+func TestUseLake(t *testing.T) {
+	var tests = []struct {
+		holder string
+		target string
+		want   Loyalty
+		err    error
+	}{
+		{"B", "C", LoyaltyUnknown, ErrNotLakeHolder},
+		{"A", "Z", LoyaltyUnknown, ErrPlayerNotFound},
+		{"A", "A", LoyaltyUnknown, ErrPreviousLakeHolder},
+		{"A", "B", LoyaltyGood, nil},
+		{"A", "E", LoyaltyEvil, nil},
+	}
+
+	for _, test := range tests {
+		avalon := newLakeAvalon()
+
+		loyalty, err := avalon.UseLake(test.holder, test.target)
+		if err != test.err {
+			t.Errorf("wanted %v for %s using lake on %s, got %v", test.err, test.holder, test.target, err)
+			continue
+		}
+		if loyalty != test.want {
+			t.Errorf("wanted %s for %s, got %s", test.want, test.target, loyalty)
+		}
+
+		if err != nil {
+			if avalon.Phase != PhaseLadyOfTheLake || avalon.CurrentLake != "A" {
+				t.Errorf("rejected lake changed state: %s, %s", avalon.Phase, avalon.CurrentLake)
+			}
+			continue
+		}
+
+		if avalon.Phase != PhaseProposing || avalon.CurrentLake != test.target {
+			t.Errorf("expected %s holding lake while proposing, got %s during %s",
+				test.target, avalon.CurrentLake, avalon.Phase)
+		}
+	}
+}
+
+func TestLakeHistory(t *testing.T) {
+	avalon := newLakeAvalon()
+
+	if holders := avalon.LakeHolders(); !reflect.DeepEqual(holders, []string{"A"}) {
+		t.Errorf("expected only A to have held lake, got %v", holders)
+	}
+
+	if _, err := avalon.UseLake("A", "E"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avalon.ClaimLake("E", LoyaltyGood); err != ErrNoLakeToClaim {
+		t.Errorf("wanted %v for wrong claimant, got %v", ErrNoLakeToClaim, err)
+	}
+	if err := avalon.ClaimLake("A", LoyaltyUnknown); err != ErrInvalidAnswer {
+		t.Errorf("wanted %v for an unknown claim, got %v", ErrInvalidAnswer, err)
+	}
+	if err := avalon.ClaimLake("A", LoyaltyGood); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := avalon.ClaimLake("A", LoyaltyEvil); err != ErrNoLakeToClaim {
+		t.Errorf("wanted %v for second claim, got %v", ErrNoLakeToClaim, err)
+	}
+
+	avalon.Phase = PhaseLadyOfTheLake
+	avalon.CurrentQuest = 3
+	avalon.QuestHistory = append(avalon.QuestHistory, QuestRecord{Quest: 2, Resolved: true})
+	if _, err := avalon.UseLake("E", "A"); err != ErrPreviousLakeHolder {
+		t.Errorf("wanted %v, got %v", ErrPreviousLakeHolder, err)
+	}
+	if _, err := avalon.UseLake("E", "C"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if holders := avalon.LakeHolders(); !reflect.DeepEqual(holders, []string{"A", "E", "C"}) {
+		t.Errorf("expected chain A, E, C, got %v", holders)
+	}
+
+	want := []LakeUse{
+		{Quest: 1, Holder: "A", Target: "E", Shown: LoyaltyEvil, Claim: LoyaltyGood},
+		{Quest: 2, Holder: "E", Target: "C", Shown: LoyaltyGood},
+	}
+	if !reflect.DeepEqual(avalon.LakeUses, want) {
+		t.Errorf("expected %+v, got %+v", want, avalon.LakeUses)
+	}
+	if view := avalon.KnowledgeFor("A"); !reflect.DeepEqual(view.Investigated, map[string]Loyalty{"E": LoyaltyEvil}) {
+		t.Errorf("expected A to have been shown E as evil, got %v", view.Investigated)
+	}
+	if view := avalon.KnowledgeFor("C"); view.Investigated != nil {
+		t.Errorf("expected C to have been shown nothing, got %v", view.Investigated)
+	}
+}
+
+func TestClaimLakePhase(t *testing.T) {
+	for _, phase := range []Phase{PhaseLobby, PhaseAssigned, PhaseGameOver} {
+		avalon := newLakeAvalon()
+		if _, err := avalon.UseLake("A", "E"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		avalon.Phase = phase
+		var perr *PhaseError
+		if err := avalon.ClaimLake("A", LoyaltyGood); !errors.As(err, &perr) {
+			t.Errorf("wanted a phase error claiming during %s, got %v", phase, err)
+		}
+		if claim := avalon.LakeUses[0].Claim; claim != LoyaltyUnknown {
+			t.Errorf("rejected claim during %s was recorded as %s", phase, claim)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package avalon
+
+// With the lancelot option, Good and Evil Lancelot are in the game and a
+// loyalty deck decides whether they swap sides. As in the published rules,
+// the deck holds lancelotDeckSize cards, lancelotSwitches of which are switch
+// cards and the rest no change cards, and is shuffled when roles are
+// assigned. One card is flipped at the start of each of quests 3 to 5; every
+// switch card flipped swaps the Lancelots' sides.
+//
+// Two variants change what players know:
+//
+//   - lancelotsknow: the Lancelots know who each other are.
+//   - lancelotreveal: the cards for quests 3 to 5 are face up from the start,
+//     so everyone knows when the switches will happen.
+const (
+	lancelotDeckSize = 7
+	lancelotSwitches = 2
+
+	// The first quest, counting from 0, with a loyalty card flipped before
+	// it, and the number of cards flipped in a game: one for it and one for
+	// each quest after it.
+	lancelotFirstQuest = 2
+	lancelotFlips      = 3
+)
+
+// shuffleLancelotDeck returns a shuffled loyalty deck, with true for each
+// switch card.
+func shuffleLancelotDeck(random Random) []bool {
+	deck := make([]bool, lancelotDeckSize)
+	for i, n := range random.Perm(lancelotDeckSize) {
+		deck[i] = n < lancelotSwitches
+	}
+
+	return deck
+}
+
+// LancelotCards returns the loyalty cards that have been flipped so far, with
+// true for each switch card. With the lancelotreveal option, every card that
+// will be flipped is shown from the start.
+func (av *Avalon) LancelotCards() []bool {
+	if av.IsOptionEnabled("lancelotreveal") && len(av.LancelotDeck) >= lancelotFlips {
+		return copyBools(av.LancelotDeck[:lancelotFlips])
+	}
+
+	return copyBools(av.LancelotDeck[:av.LancelotFlips])
+}
+
+// LancelotsSwitched returns whether the Lancelots are currently on the
+// opposite sides to the ones they were dealt.
+func (av *Avalon) LancelotsSwitched() bool {
+	var switched bool
+	for _, card := range av.LancelotDeck[:av.LancelotFlips] {
+		if card {
+			switched = !switched
+		}
+	}
+
+	return switched
+}
+
+// isLancelot returns whether the given player is Good or Evil Lancelot.
+func (av *Avalon) isLancelot(nick string) bool {
+	return av.RoleOf(nick) == "goodlancelot" || av.RoleOf(nick) == "evillancelot"
+}
+
+// flipLancelotCard flips the next loyalty card if a Lancelot quest is about to
+// start.
+func (av *Avalon) flipLancelotCard() {
+	if !av.IsOptionEnabled("lancelot") || av.CurrentQuest < lancelotFirstQuest {
+		return
+	}
+
+	if av.LancelotFlips < lancelotFlips && av.LancelotFlips < len(av.LancelotDeck) {
+		av.LancelotFlips++
+	}
+}
@@ -0,0 +1,202 @@
+package avalon
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newLancelotAvalon(t *testing.T, options []string, deck []bool) *Avalon {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, append([]string{"lancelot"}, options...))
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	av.LancelotDeck = deck
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return av
+}
+
+func TestShuffleLancelotDeck(t *testing.T) {
+	// The published deck has two switch cards and five no change cards
+	for seed := int64(0); seed < 20; seed++ {
+		deck := shuffleLancelotDeck(rand.New(rand.NewSource(seed)))
+		if len(deck) != 7 {
+			t.Fatalf("wanted 7 cards, got %d", len(deck))
+		}
+
+		var switches, noChanges int
+		for _, card := range deck {
+			if card {
+				switches++
+			} else {
+				noChanges++
+			}
+		}
+		if switches != 2 || noChanges != 5 {
+			t.Errorf("wanted 2 switch and 5 no change cards, got %d and %d in %v", switches, noChanges, deck)
+		}
+	}
+
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.LancelotDeck != nil {
+		t.Errorf("expected no loyalty deck without lancelot, got %v", av.LancelotDeck)
+	}
+}
+
+func TestLancelotSwitch(t *testing.T) {
+	av := newLancelotAvalon(t, nil, []bool{true, false, true, false, false, false, false})
+	good, evil := av.Specials["goodlancelot"], av.Specials["evillancelot"]
+
+	playQuest(t, av, true)
+	if len(av.LancelotCards()) != 0 || av.LancelotsSwitched() {
+		t.Fatalf("expected no cards flipped after quest 1, got %v", av.LancelotCards())
+	}
+
+	playQuest(t, av, true)
+	if !reflect.DeepEqual(av.LancelotCards(), []bool{true}) || !av.LancelotsSwitched() {
+		t.Fatalf("expected a switch before quest 3, got %v", av.LancelotCards())
+	}
+	if !av.IsEvil(good) || !av.IsGood(evil) {
+		t.Errorf("expected %s evil and %s good, got %s and %s", good, evil, av.LoyaltyOf(good), av.LoyaltyOf(evil))
+	}
+	if view := av.KnowledgeFor(good); view.Loyalty != LoyaltyGood {
+		t.Errorf("expected %s to be shown the side they were dealt, got %s", good, view.Loyalty)
+	}
+
+	// Only the side a Lancelot is on now decides which cards they may play
+	party := []string{good, evil, av.Goods[0]}
+	for _, nick := range av.Goods {
+		if nick != good {
+			party[2] = nick
+			break
+		}
+	}
+	if err := av.ProposeParty(av.CurrentLeader, party); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	if err := av.PlayQuestCard(evil, false); err != ErrGoodMustSucceed {
+		t.Errorf("wanted %v, got %v", ErrGoodMustSucceed, err)
+	}
+	for _, nick := range party {
+		if err := av.PlayQuestCard(nick, nick != good); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if av.NumFails() != 1 || !reflect.DeepEqual(av.LancelotCards(), []bool{true, false}) || !av.LancelotsSwitched() {
+		t.Fatalf("expected quest 3 failed and still switched, got %d fails and %v", av.NumFails(), av.LancelotCards())
+	}
+
+	playQuest(t, av, true)
+	if av.Phase != PhaseAssassination {
+		t.Fatalf("expected %s, got %s", PhaseAssassination, av.Phase)
+	}
+	if len(av.LancelotCards()) != 2 {
+		t.Errorf("expected no card flipped once the quests are over, got %v", av.LancelotCards())
+	}
+
+	target := av.Goods[0]
+	if target == av.Specials["merlin"] || target == good {
+		target = av.Goods[1]
+	}
+	if target == av.Specials["merlin"] || target == good {
+		target = av.Goods[2]
+	}
+	if err := av.Assassinate(av.Specials["assassin"], target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	winners, err := av.Winners()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !contains(winners, evil) || contains(winners, good) || len(winners) != 4 {
+		t.Errorf("expected the switched Lancelots to change which side they won with, got %v", winners)
+	}
+}
+
+func TestLancelotLake(t *testing.T) {
+	av := newLancelotAvalon(t, []string{"lake"}, []bool{true, false, false, true, false, false, false})
+	good := av.Specials["goodlancelot"]
+	target, want := good, LoyaltyGood
+	if target == av.CurrentLake {
+		target, want = av.Specials["evillancelot"], LoyaltyEvil
+	}
+
+	playQuest(t, av, true)
+	playQuest(t, av, true)
+	if av.Phase != PhaseLadyOfTheLake {
+		t.Fatalf("expected %s after quest 2, got %s", PhaseLadyOfTheLake, av.Phase)
+	}
+	if len(av.LancelotCards()) != 0 || av.LancelotsSwitched() {
+		t.Fatalf("expected no card flipped before the lady of the lake, got %v", av.LancelotCards())
+	}
+
+	// The Lady sees the Lancelots as they were before quest 3's card flips
+	loyalty, err := av.UseLake(av.CurrentLake, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loyalty != want {
+		t.Errorf("expected the lady of the lake to see %s, got %s", want, loyalty)
+	}
+	if !reflect.DeepEqual(av.LancelotCards(), []bool{true}) || !av.LancelotsSwitched() {
+		t.Errorf("expected a switch once quest 3 starts, got %v", av.LancelotCards())
+	}
+}
+
+func TestLancelotReveal(t *testing.T) {
+	deck := []bool{false, true, true, false, false, false, false}
+
+	av := newLancelotAvalon(t, nil, deck)
+	if cards := av.LancelotCards(); len(cards) != 0 {
+		t.Errorf("expected no cards shown before quest 3, got %v", cards)
+	}
+
+	av = newLancelotAvalon(t, []string{"lancelotreveal"}, deck)
+	if cards := av.LancelotCards(); !reflect.DeepEqual(cards, deck[:lancelotFlips]) {
+		t.Errorf("expected %v shown up front, got %v", deck[:lancelotFlips], cards)
+	}
+	if av.LancelotsSwitched() {
+		t.Error("expected revealed cards not to take effect before they are flipped")
+	}
+}
+
+func TestLancelotKnowledge(t *testing.T) {
+	var tests = []struct {
+		options []string
+		good    []string
+		evil    []string
+	}{
+		{nil, nil, nil},
+		{[]string{"lancelotsknow"}, []string{"B"}, []string{"A"}},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E"}
+		avalon.Goods = []string{"A", "C", "D"}
+		avalon.Evils = []string{"B", "E"}
+		avalon.EnableMany(append([]string{"lancelot"}, test.options...))
+		avalon.Specials = map[string]string{"merlin": "C", "assassin": "E", "goodlancelot": "A", "evillancelot": "B"}
+
+		good := avalon.KnowledgeFor("A")
+		if !reflect.DeepEqual(good.Candidates["evillancelot"], test.good) || good.KnownEvils != nil {
+			t.Errorf("with %v, expected Good Lancelot to see %v, got %+v", test.options, test.good, good)
+		}
+
+		evil := avalon.KnowledgeFor("B")
+		if !reflect.DeepEqual(evil.Candidates["goodlancelot"], test.evil) {
+			t.Errorf("with %v, expected Evil Lancelot to see %v, got %+v", test.options, test.evil, evil)
+		}
+		if !reflect.DeepEqual(evil.KnownEvils, []string{"E"}) {
+			t.Errorf("expected Evil Lancelot to know the other evils, got %v", evil.KnownEvils)
+		}
+	}
+}
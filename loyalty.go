@@ -0,0 +1,105 @@
+package avalon
+
+import (
+	"fmt"
+)
+
+// Loyalty is the side a player is on.
+type Loyalty int
+
+// Loyalties a player can have. LoyaltyUnknown is the zero value.
+const (
+	LoyaltyUnknown Loyalty = iota
+	LoyaltyGood
+	LoyaltyEvil
+)
+
+var loyaltyNames = map[Loyalty]string{
+	LoyaltyUnknown: "unknown",
+	LoyaltyGood:    "good",
+	LoyaltyEvil:    "evil",
+}
+
+// String returns the human-readable name of the loyalty.
+func (l Loyalty) String() string {
+	name, ok := loyaltyNames[l]
+	if !ok {
+		return fmt.Sprintf("loyalty(%d)", int(l))
+	}
+
+	return name
+}
+
+// MarshalText encodes the loyalty as its name.
+func (l Loyalty) MarshalText() ([]byte, error) {
+	name, ok := loyaltyNames[l]
+	if !ok {
+		return nil, fmt.Errorf("avalon: unknown loyalty %d", int(l))
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a loyalty from its name.
+func (l *Loyalty) UnmarshalText(text []byte) error {
+	for loyalty, name := range loyaltyNames {
+		if name == string(text) {
+			*l = loyalty
+			return nil
+		}
+	}
+
+	return fmt.Errorf("avalon: unknown loyalty %q", text)
+}
+
+// IsGood returns whether the given player is currently on the side of good.
+func (av *Avalon) IsGood(nick string) bool {
+	return av.LoyaltyOf(nick) == LoyaltyGood
+}
+
+// IsEvil returns whether the given player is currently on the side of evil.
+func (av *Avalon) IsEvil(nick string) bool {
+	return av.LoyaltyOf(nick) == LoyaltyEvil
+}
+
+// LoyaltyOf returns the side the given player is currently on, or
+// LoyaltyUnknown if they have not been assigned one. This is the side they
+// were dealt unless they are a Lancelot and the Lancelots have switched, or
+// they are the Untrustworthy Servant and have told the Assassin.
+func (av *Avalon) LoyaltyOf(nick string) Loyalty {
+	loyalty := av.dealtLoyalty(nick)
+	if av.isLancelot(nick) && av.LancelotsSwitched() {
+		return loyalty.opposite()
+	}
+
+	if av.ServantTurned && nick == av.Specials["untrustworthyservant"] {
+		return LoyaltyEvil
+	}
+
+	return loyalty
+}
+
+// dealtLoyalty returns the side the given player was dealt at the start of
+// the game.
+func (av *Avalon) dealtLoyalty(nick string) Loyalty {
+	switch {
+	case contains(av.Goods, nick):
+		return LoyaltyGood
+	case contains(av.Evils, nick):
+		return LoyaltyEvil
+	default:
+		return LoyaltyUnknown
+	}
+}
+
+// opposite returns the other side, or LoyaltyUnknown for LoyaltyUnknown.
+func (l Loyalty) opposite() Loyalty {
+	switch l {
+	case LoyaltyGood:
+		return LoyaltyEvil
+	case LoyaltyEvil:
+		return LoyaltyGood
+	default:
+		return LoyaltyUnknown
+	}
+}
@@ -0,0 +1,28 @@
+package avalon
+
+import (
+	"testing"
+)
+
+func TestLoyaltyOf(t *testing.T) {
+	var tests = []struct {
+		nick string
+		want Loyalty
+	}{
+		{"A", LoyaltyGood},
+		{"D", LoyaltyEvil},
+		{"Z", LoyaltyUnknown},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E"}
+		avalon.Goods = []string{"A", "B", "C"}
+		avalon.Evils = []string{"D", "E"}
+
+		loyalty := avalon.LoyaltyOf(test.nick)
+		if loyalty != test.want {
+			t.Errorf("wanted %s for %s, got %s", test.want, test.nick, loyalty)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotLeader indicates that someone other than the current leader tried
+	// to propose a party.
+	ErrNotLeader = errors.New("avalon: only the current leader may propose a party")
+
+	// ErrWrongPartySize indicates that a proposed party does not match the
+	// size required for the current quest.
+	ErrWrongPartySize = errors.New("avalon: party is the wrong size for this quest")
+
+	// ErrDuplicatePartyMember indicates that a proposed party names the same
+	// player more than once.
+	ErrDuplicatePartyMember = errors.New("avalon: party contains a player more than once")
+)
+
+// QuestSize returns the number of players that must go on the given quest
+// (zero-indexed) or 0 if there is no such quest for this many players.
+func (av *Avalon) QuestSize(quest int) int {
+	sizes, ok := numPlayersToQuestSizes[av.NumPlayers()]
+	if !ok || quest < 0 || quest >= len(sizes) {
+		return 0
+	}
+
+	return sizes[quest]
+}
+
+// CurrentQuestSize returns the number of players that must go on the current
+// quest, which is the targeted quest with the targeting option enabled.
+func (av *Avalon) CurrentQuestSize() int {
+	return av.QuestSize(av.targetQuest())
+}
+
+// PartyProposal is everything a leader decides when they propose a party.
+// Excalibur is the party member given Excalibur, with the excalibur option,
+// and Quest is the quest (zero-indexed) the party attempts, with the
+// targeting option. Without the targeting option, Quest is ignored and the
+// party attempts the current quest.
+type PartyProposal struct {
+	Party     []string
+	Excalibur string
+	Quest     int
+}
+
+// ProposeParty validates and records the party proposed by leader and opens
+// it up for a vote. The leader must be the current leader and the party must
+// be made up of the right number of distinct players for the current quest.
+// With the excalibur option enabled, use ProposePartyWithExcalibur instead,
+// and with the targeting option enabled, use Propose.
+func (av *Avalon) ProposeParty(leader string, party []string) error {
+	return av.Propose(leader, PartyProposal{Party: party, Quest: noTarget})
+}
+
+// Propose validates and records a proposal exactly like ProposeParty, along
+// with whatever else the options enabled need the leader to decide.
+func (av *Avalon) Propose(leader string, proposal PartyProposal) error {
+	if err := av.expectPhase("propose a party", PhaseProposing); err != nil {
+		return err
+	}
+
+	if leader != av.CurrentLeader {
+		return ErrNotLeader
+	}
+
+	if av.plotCardsPending() {
+		return ErrPlotCardsPending
+	}
+
+	quest, err := av.proposedQuest(proposal.Quest)
+	if err != nil {
+		return err
+	}
+
+	if err := av.validateParty(proposal.Party, quest); err != nil {
+		return err
+	}
+
+	if err := av.validateExcalibur(leader, proposal.Party, proposal.Excalibur); err != nil {
+		return err
+	}
+
+	av.record(Event{
+		Type:   EventPartyProposed,
+		Player: leader,
+		Party:  copyStrings(proposal.Party),
+		Target: proposal.Excalibur,
+		Quest:  quest,
+	})
+	return nil
+}
+
+func (av *Avalon) applyPartyProposed(ev Event) {
+	av.CurrentProposedParty = copyStrings(ev.Party)
+	av.CurrentExcalibur = ev.Target
+	if av.IsOptionEnabled("targeting") {
+		av.CurrentTarget = ev.Quest
+	}
+	av.Phase = PhaseVoting
+}
+
+func (av *Avalon) validateParty(party []string, quest int) error {
+	if len(party) != av.QuestSize(quest) {
+		return ErrWrongPartySize
+	}
+
+	seen := make(map[string]bool, len(party))
+	for _, nick := range party {
+		if !av.PlayerExists(nick) {
+			return ErrPlayerNotFound
+		}
+
+		if seen[nick] {
+			return ErrDuplicatePartyMember
+		}
+		seen[nick] = true
+	}
+
+	return nil
+}
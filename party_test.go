@@ -0,0 +1,75 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuestSize(t *testing.T) {
+	var tests = []struct {
+		players []string
+		quest   int
+		want    int
+	}{
+		{[]string{"A", "B", "C", "D"}, 0, 0},
+		{[]string{"A", "B", "C", "D", "E"}, 0, 2},
+		{[]string{"A", "B", "C", "D", "E", "F"}, 2, 4},
+		{[]string{"A", "B", "C", "D", "E", "F", "G"}, 4, 4},
+		{[]string{"A", "B", "C", "D", "E", "F", "G", "H"}, 3, 5},
+		{[]string{"A", "B", "C", "D", "E"}, 5, 0},
+		{[]string{"A", "B", "C", "D", "E"}, -1, 0},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = test.players
+
+		n := avalon.QuestSize(test.quest)
+		if n != test.want {
+			t.Errorf("wanted %d for quest %d with %d players, got %d",
+				test.want, test.quest, avalon.NumPlayers(), n)
+		}
+	}
+}
+
+func TestProposeParty(t *testing.T) {
+	var tests = []struct {
+		leader string
+		party  []string
+		want   error
+	}{
+		{"B", []string{"A", "B"}, ErrNotLeader},
+		{"A", []string{"A"}, ErrWrongPartySize},
+		{"A", []string{"A", "B", "C"}, ErrWrongPartySize},
+		{"A", []string{"A", "Z"}, ErrPlayerNotFound},
+		{"A", []string{"A", "A"}, ErrDuplicatePartyMember},
+		{"A", []string{"C", "E"}, nil},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E"}
+		avalon.Phase = PhaseProposing
+		avalon.CurrentLeader = "A"
+
+		err := avalon.ProposeParty(test.leader, test.party)
+		if err != test.want {
+			t.Errorf("wanted %v for %s proposing %v, got %v", test.want, test.leader, test.party, err)
+			continue
+		}
+
+		if err != nil {
+			if avalon.Phase != PhaseProposing || avalon.CurrentProposedParty != nil {
+				t.Errorf("rejected proposal changed state: %s, %v", avalon.Phase, avalon.CurrentProposedParty)
+			}
+			continue
+		}
+
+		if avalon.Phase != PhaseVoting {
+			t.Errorf("expected %s, got %s", PhaseVoting, avalon.Phase)
+		}
+		if !reflect.DeepEqual(avalon.CurrentProposedParty, test.party) {
+			t.Errorf("expected party %v, got %v", test.party, avalon.CurrentProposedParty)
+		}
+	}
+}
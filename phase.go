@@ -0,0 +1,179 @@
+package avalon
+
+import (
+	"fmt"
+)
+
+// Phase identifies which stage of the game an Avalon is in. Every action on
+// the game is only legal during particular phases.
+type Phase int
+
+// Phases in the order a game normally moves through them.
+const (
+	PhaseLobby Phase = iota
+	PhaseAssigned
+	PhaseProposing
+	PhaseVoting
+	PhaseQuesting
+	PhaseExcalibur
+	PhaseInvestigation
+	PhaseLadyOfTheLake
+	PhaseAssassination
+	PhaseHunt
+	PhaseGameOver
+)
+
+var phaseNames = map[Phase]string{
+	PhaseLobby:         "lobby",
+	PhaseAssigned:      "assigned",
+	PhaseProposing:     "proposing",
+	PhaseVoting:        "voting",
+	PhaseQuesting:      "questing",
+	PhaseExcalibur:     "excalibur",
+	PhaseInvestigation: "investigation",
+	PhaseLadyOfTheLake: "lady of the lake",
+	PhaseAssassination: "assassination",
+	PhaseHunt:          "hunt",
+	PhaseGameOver:      "game over",
+}
+
+// String returns the human-readable name of the phase.
+func (p Phase) String() string {
+	name, ok := phaseNames[p]
+	if !ok {
+		return fmt.Sprintf("phase(%d)", int(p))
+	}
+
+	return name
+}
+
+// MarshalText encodes the phase as its name so that serialized games stay
+// readable and do not depend on the order of the constants.
+func (p Phase) MarshalText() ([]byte, error) {
+	name, ok := phaseNames[p]
+	if !ok {
+		return nil, fmt.Errorf("avalon: unknown phase %d", int(p))
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a phase from its name.
+func (p *Phase) UnmarshalText(text []byte) error {
+	for phase, name := range phaseNames {
+		if name == string(text) {
+			*p = phase
+			return nil
+		}
+	}
+
+	return fmt.Errorf("avalon: unknown phase %q", text)
+}
+
+// PhaseError indicates that an action was attempted while the game was in a
+// phase that does not allow it.
+type PhaseError struct {
+	Action string
+	Phase  Phase
+}
+
+func (e *PhaseError) Error() string {
+	return fmt.Sprintf("avalon: cannot %s during the %s phase", e.Action, e.Phase)
+}
+
+// expectPhase returns a PhaseError for action unless the game is currently in
+// one of the given phases.
+func (av *Avalon) expectPhase(action string, phases ...Phase) error {
+	for _, phase := range phases {
+		if av.Phase == phase {
+			return nil
+		}
+	}
+
+	return &PhaseError{Action: action, Phase: av.Phase}
+}
+
+// Start validates the config against the players who have joined, assigns
+// every role and moves the game out of the lobby. Players should be told
+// their roles before BeginQuests is called. With the secure option enabled,
+// roles are drawn from crypto/rand regardless of SetRandom.
+func (av *Avalon) Start() error {
+	if err := av.canStart(); err != nil {
+		return err
+	}
+
+	random := av.random
+	if av.IsOptionEnabled("secure") {
+		random = SecureRandom{}
+	}
+
+	ass := NewAssigner(av, random)
+	if av.strategy != nil {
+		ass.Strategy = av.strategy
+	}
+
+	if err := ass.Assign(); err != nil {
+		av.setAssignment(&Assignment{})
+		return err
+	}
+
+	av.record(Event{Type: EventRolesAssigned, Assignment: av.assignment(), Salt: newSalt()})
+	return nil
+}
+
+// startWith starts the game with a known assignment rather than assigning
+// roles afresh, which is how a recorded game is replayed.
+func (av *Avalon) startWith(assignment *Assignment, salt string) error {
+	if err := av.canStart(); err != nil {
+		return err
+	}
+
+	if assignment == nil {
+		return ErrMissingAssignment
+	}
+
+	if err := av.validateAssignment(assignment); err != nil {
+		return err
+	}
+
+	av.record(Event{Type: EventRolesAssigned, Assignment: assignment.copy(), Salt: salt})
+	return nil
+}
+
+func (av *Avalon) canStart() error {
+	if err := av.expectPhase("start the game", PhaseLobby); err != nil {
+		return err
+	}
+
+	if av.NumPlayers() < MinPlayers {
+		return ErrNotEnoughPlayers
+	}
+
+	return av.IsValid()
+}
+
+func (av *Avalon) applyRolesAssigned(ev Event) {
+	av.setAssignment(ev.Assignment)
+	av.PendingAnswer = av.clericSuspect()
+	av.salt = ev.Salt
+	av.Commitment = commit(ev.Salt, ev.Assignment)
+	av.Phase = PhaseAssigned
+}
+
+// BeginQuests ends the night phase and lets the first leader propose a party.
+func (av *Avalon) BeginQuests() error {
+	if err := av.expectPhase("begin the quests", PhaseAssigned); err != nil {
+		return err
+	}
+
+	if av.PendingAnswer != "" {
+		return ErrAnswerPending
+	}
+
+	av.record(Event{Type: EventQuestsBegun})
+	return nil
+}
+
+func (av *Avalon) applyQuestsBegun(ev Event) {
+	av.startQuest()
+}
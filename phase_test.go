@@ -0,0 +1,224 @@
+package avalon
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestAvalon(t *testing.T, players []string, options []string) *Avalon {
+	av := NewAvalon()
+	for _, player := range players {
+		if err := av.AddPlayer(player); err != nil {
+			t.Fatalf("unexpected error adding %s: %v", player, err)
+		}
+	}
+	av.EnableMany(options)
+
+	return av
+}
+
+func TestPhaseString(t *testing.T) {
+	var tests = []struct {
+		phase Phase
+		want  string
+	}{
+		{PhaseLobby, "lobby"},
+		{PhaseLadyOfTheLake, "lady of the lake"},
+		{PhaseGameOver, "game over"},
+		{Phase(42), "phase(42)"},
+	}
+
+	for _, test := range tests {
+		if s := test.phase.String(); s != test.want {
+			t.Errorf("wanted %s, got %s", test.want, s)
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D"}, nil)
+	if err := av.Start(); err != ErrNotEnoughPlayers {
+		t.Errorf("wanted %v, got %v", ErrNotEnoughPlayers, err)
+	}
+
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"lake"})
+	if err := av.Start(); err == nil {
+		t.Error("expected invalid config error, got no error")
+	}
+	if av.Phase != PhaseLobby {
+		t.Errorf("expected to stay in %s, got %s", PhaseLobby, av.Phase)
+	}
+
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.Phase != PhaseAssigned {
+		t.Errorf("expected %s, got %s", PhaseAssigned, av.Phase)
+	}
+	runRequirementTests(t, av)
+
+	var perr *PhaseError
+	if err := av.Start(); !errors.As(err, &perr) {
+		t.Errorf("expected PhaseError starting twice, got %v", err)
+	}
+	if err := av.AddPlayer("F"); !errors.As(err, &perr) {
+		t.Errorf("expected PhaseError joining a started game, got %v", err)
+	}
+}
+
+func TestOutOfPhaseActions(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+
+	actions := map[string]func() error{
+		"BeginQuests":   av.BeginQuests,
+		"ProposeParty":  func() error { return av.ProposeParty("A", []string{"A", "B"}) },
+		"Vote":          func() error { return av.Vote("A", true) },
+		"PlayQuestCard": func() error { return av.PlayQuestCard("A", true) },
+		"UseLake":       func() error { _, err := av.UseLake("A", "B"); return err },
+		"Assassinate":   func() error { return av.Assassinate("A", "B") },
+	}
+
+	for name, action := range actions {
+		var perr *PhaseError
+		err := action()
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: expected PhaseError in lobby, got %v", name, err)
+			continue
+		}
+		if perr.Phase != PhaseLobby {
+			t.Errorf("%s: expected error for %s, got %s", name, PhaseLobby, perr.Phase)
+		}
+	}
+}
+
+func validParty(av *Avalon) []string {
+	return av.Players[:av.CurrentQuestSize()]
+}
+
+// failingParty returns a party for the current quest with enough evils on it
+// to fail the quest, followed by goods.
+func failingParty(av *Avalon) []string {
+	n := av.FailsRequired(av.targetQuest())
+	party := append([]string{}, av.Evils[:n]...)
+	return append(party, av.Goods[:av.CurrentQuestSize()-n]...)
+}
+
+func voteAll(t *testing.T, av *Avalon, approve bool) {
+	for _, nick := range av.Players {
+		if err := av.Vote(nick, approve); err != nil {
+			t.Fatalf("unexpected error voting: %v", err)
+		}
+	}
+}
+
+func playQuest(t *testing.T, av *Avalon, success bool) {
+	party := validParty(av)
+	if !success {
+		party = failingParty(av)
+	}
+
+	if err := av.ProposeParty(av.CurrentLeader, party); err != nil {
+		t.Fatalf("unexpected error proposing: %v", err)
+	}
+	voteAll(t, av, true)
+
+	for _, nick := range party {
+		if err := av.PlayQuestCard(nick, success || av.IsGood(nick)); err != nil {
+			t.Fatalf("unexpected error questing: %v", err)
+		}
+	}
+}
+
+func TestPhaseTransitions(t *testing.T) {
+	// Good wins three quests and moves to the assassination
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	playQuest(t, av, true)
+	playQuest(t, av, false)
+	playQuest(t, av, true)
+	if av.Phase != PhaseProposing {
+		t.Errorf("expected %s after two successes, got %s", PhaseProposing, av.Phase)
+	}
+	playQuest(t, av, true)
+	if av.Phase != PhaseAssassination {
+		t.Errorf("expected %s after three successes, got %s", PhaseAssassination, av.Phase)
+	}
+	if err := av.Assassinate(av.Specials["assassin"], av.Specials["merlin"]); err != nil {
+		t.Fatalf("unexpected error assassinating: %v", err)
+	}
+	if av.Phase != PhaseGameOver {
+		t.Errorf("expected %s, got %s", PhaseGameOver, av.Phase)
+	}
+	if winner, _ := av.Winner(); winner != LoyaltyEvil {
+		t.Errorf("expected %s to win by assassinating Merlin, got %s", LoyaltyEvil, winner)
+	}
+
+	// Evil fails three quests and the game ends immediately
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		playQuest(t, av, false)
+	}
+	if av.Phase != PhaseGameOver {
+		t.Errorf("expected %s after three fails, got %s", PhaseGameOver, av.Phase)
+	}
+
+	// A rejected party goes back to proposing and advances the vote track
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, false)
+	if av.Phase != PhaseProposing || av.VoteTrack != 1 {
+		t.Errorf("expected %s with vote track 1, got %s with %d", PhaseProposing, av.Phase, av.VoteTrack)
+	}
+}
+
+func TestLakePhase(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"lake"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	playQuest(t, av, true)
+	if av.Phase != PhaseProposing {
+		t.Errorf("expected no lake after quest 1, got %s", av.Phase)
+	}
+
+	playQuest(t, av, false)
+	if av.Phase != PhaseLadyOfTheLake {
+		t.Fatalf("expected %s after quest 2, got %s", PhaseLadyOfTheLake, av.Phase)
+	}
+
+	target := av.Players[0]
+	if target == av.CurrentLake {
+		target = av.Players[1]
+	}
+	if _, err := av.UseLake(av.CurrentLake, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.CurrentLake != target || av.Phase != PhaseProposing {
+		t.Errorf("expected lake with %s while proposing, got %s during %s", target, av.CurrentLake, av.Phase)
+	}
+}
@@ -0,0 +1,429 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrPlotCardNotHeld indicates that a player tried to deal or use a plot
+	// card they do not have.
+	ErrPlotCardNotHeld = errors.New("avalon: player does not hold that plot card")
+
+	// ErrPlotCardToLeader indicates that the leader tried to deal a plot card
+	// to themselves.
+	ErrPlotCardToLeader = errors.New("avalon: the leader cannot deal plot cards to themselves")
+
+	// ErrPlotCardsPending indicates that a party was proposed before every
+	// plot card drawn this round was dealt and every immediate card was used.
+	ErrPlotCardsPending = errors.New("avalon: plot cards must be dealt and used before proposing")
+
+	// ErrPlotCardNotUsable indicates that a plot card was used at the wrong
+	// time or on a player it cannot be used on.
+	ErrPlotCardNotUsable = errors.New("avalon: plot card cannot be used like that now")
+
+	// ErrOpinionMakerFirst indicates that a player voted before everyone
+	// holding Opinion Maker.
+	ErrOpinionMakerFirst = errors.New("avalon: opinion makers must vote first")
+)
+
+// Names of the plot cards.
+const (
+	PlotTakeResponsibility    = "takeresponsibility"
+	PlotOverheardConversation = "overheardconversation"
+	PlotEstablishConfidence   = "establishconfidence"
+	PlotStrongLeader          = "strongleader"
+	PlotRestoreHonor          = "restorehonor"
+	PlotLeadToVictory         = "leadtovictory"
+	PlotOpinionMaker          = "opinionmaker"
+	PlotNoConfidence          = "noconfidence"
+	PlotInTheSpotlight        = "inthespotlight"
+	PlotKeepingCloseWatch     = "keepingclosewatch"
+)
+
+// plotCard describes one kind of plot card: how many copies are in the deck
+// for 5-6 and for 7+ players and when it can be used. Immediate cards must be
+// used as soon as they are dealt, before the leader proposes. Permanent cards
+// are never used up and take effect for as long as they are held. Every other
+// card is held until its holder uses it during Phase.
+type plotCard struct {
+	Name      string
+	Small     int
+	Large     int
+	Immediate bool
+	Permanent bool
+	Phase     Phase
+	Text      string
+}
+
+var plotCards = []plotCard{
+	{
+		Name: PlotTakeResponsibility, Small: 1, Large: 1, Immediate: true, Phase: PhaseProposing,
+		Text: "Take a plot card from another player.",
+	},
+	{
+		Name: PlotOverheardConversation, Small: 1, Large: 2, Immediate: true, Phase: PhaseProposing,
+		Text: "Learn the loyalty of a player next to you.",
+	},
+	{
+		Name: PlotEstablishConfidence, Small: 1, Large: 1, Immediate: true, Phase: PhaseProposing,
+		Text: "Reveal your loyalty to a player of your choice.",
+	},
+	{
+		Name: PlotStrongLeader, Small: 1, Large: 2, Phase: PhaseProposing,
+		Text: "Before a party is proposed, become the leader.",
+	},
+	{
+		Name: PlotRestoreHonor, Small: 1, Large: 1, Phase: PhaseProposing,
+		Text: "Before a party is proposed, move the vote track back one space.",
+	},
+	{
+		Name: PlotLeadToVictory, Small: 1, Large: 1, Phase: PhaseVoting,
+		Text: "As leader, send your party on the quest without a vote.",
+	},
+	{
+		Name: PlotOpinionMaker, Small: 1, Large: 2, Permanent: true,
+		Text: "You must vote before everyone else on every party.",
+	},
+	{
+		Name: PlotNoConfidence, Small: 1, Large: 2, Phase: PhaseQuesting,
+		Text: "Before any quest card is played, reject the approved party.",
+	},
+	{
+		Name: PlotInTheSpotlight, Small: 1, Large: 1, Phase: PhaseQuesting,
+		Text: "Before a party member plays their quest card, make them play it face up.",
+	},
+	{
+		Name: PlotKeepingCloseWatch, Small: 1, Large: 2, Phase: PhaseQuesting,
+		Text: "Look at the quest card a party member has played.",
+	},
+}
+
+// lookupPlotCard returns the plot card with the given name.
+func lookupPlotCard(name string) (plotCard, bool) {
+	for _, card := range plotCards {
+		if card.Name == name {
+			return card, true
+		}
+	}
+
+	return plotCard{}, false
+}
+
+// PlotCardText returns what the given plot card does, or "" if there is no
+// such card.
+func PlotCardText(name string) string {
+	card, _ := lookupPlotCard(name)
+	return card.Text
+}
+
+// newPlotDeck returns a shuffled plot deck for the given number of players.
+func newPlotDeck(numPlayers int, random Random) []string {
+	var cards []string
+	for _, card := range plotCards {
+		copies := card.Small
+		if numPlayers >= 7 {
+			copies = card.Large
+		}
+		for i := 0; i < copies; i++ {
+			cards = append(cards, card.Name)
+		}
+	}
+
+	deck := make([]string, 0, len(cards))
+	for _, n := range random.Perm(len(cards)) {
+		deck = append(deck, cards[n])
+	}
+
+	return deck
+}
+
+// PlotUse is the public record of a single plot card being used. Taken is
+// the card taken with Take Responsibility.
+type PlotUse struct {
+	Quest  int    `json:"quest"`
+	Holder string `json:"holder"`
+	Card   string `json:"card"`
+	Target string `json:"target,omitempty"`
+	Taken  string `json:"taken,omitempty"`
+}
+
+// PlotReveal is the private information a plot card gives: the loyalty seen
+// with Overheard Conversation or shown with Establish Confidence, or the
+// quest card seen with Keeping Close Watch. Only the field for the card used
+// is set.
+type PlotReveal struct {
+	Loyalty Loyalty
+	Success bool
+}
+
+// startRound hands the game to the current leader to propose a party. With
+// the targeting option, the first quest that may be attempted is targeted
+// until the leader proposes a party for the quest they choose. With the plot
+// option, the leader first draws plot cards from the deck to deal out.
+func (av *Avalon) startRound() {
+	av.Phase = PhaseProposing
+	if av.IsOptionEnabled("targeting") {
+		av.CurrentTarget = av.defaultTarget()
+	}
+
+	if !av.IsOptionEnabled("plot") {
+		return
+	}
+
+	n := numPlayersToPlotCards[av.NumPlayers()]
+	if remaining := len(av.PlotDeck) - av.PlotDrawn; n > remaining {
+		n = remaining
+	}
+	av.CurrentPlotCards = copyStrings(av.PlotDeck[av.PlotDrawn : av.PlotDrawn+n])
+	av.PlotDrawn += n
+}
+
+// PlotCardsOf returns the plot cards the given player holds.
+func (av *Avalon) PlotCardsOf(nick string) []string {
+	return copyStrings(av.PlotHands[nick])
+}
+
+// plotCardsPending returns whether the leader still has plot cards to deal or
+// anyone holds an immediate card they have not used.
+func (av *Avalon) plotCardsPending() bool {
+	if len(av.CurrentPlotCards) > 0 {
+		return true
+	}
+
+	for _, hand := range av.PlotHands {
+		for _, name := range hand {
+			if card, _ := lookupPlotCard(name); card.Immediate {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// opinionMakers returns everyone holding Opinion Maker.
+func (av *Avalon) opinionMakers() []string {
+	var holders []string
+	for _, nick := range av.Seats {
+		if contains(av.PlotHands[nick], PlotOpinionMaker) {
+			holders = append(holders, nick)
+		}
+	}
+
+	return holders
+}
+
+// validateOpinionMakers returns ErrOpinionMakerFirst if nick may not vote yet
+// because someone holding Opinion Maker has not voted.
+func (av *Avalon) validateOpinionMakers(nick string) error {
+	holders := av.opinionMakers()
+	if contains(holders, nick) {
+		return nil
+	}
+
+	for _, holder := range holders {
+		if _, ok := av.CurrentVotes[holder]; !ok {
+			return ErrOpinionMakerFirst
+		}
+	}
+
+	return nil
+}
+
+// DealPlotCard has the leader deal one of the plot cards they drew this round
+// to another player. A Take Responsibility with no other card on the table to
+// take is discarded as soon as it is dealt, or as soon as the last card it
+// could take is used.
+func (av *Avalon) DealPlotCard(leader string, card string, target string) error {
+	if err := av.expectPhase("deal a plot card", PhaseProposing); err != nil {
+		return err
+	}
+
+	if leader != av.CurrentLeader {
+		return ErrNotLeader
+	}
+
+	if !contains(av.CurrentPlotCards, card) {
+		return ErrPlotCardNotHeld
+	}
+
+	if !av.PlayerExists(target) {
+		return ErrPlayerNotFound
+	}
+
+	if target == leader {
+		return ErrPlotCardToLeader
+	}
+
+	av.record(Event{Type: EventPlotCardDealt, Player: leader, Target: target, Card: card})
+	return nil
+}
+
+func (av *Avalon) applyPlotCardDealt(ev Event) {
+	av.CurrentPlotCards = remove(av.CurrentPlotCards, ev.Card)
+
+	if ev.Card == PlotTakeResponsibility && !av.canTakeResponsibility(ev.Target) {
+		return
+	}
+
+	if av.PlotHands == nil {
+		av.PlotHands = make(map[string][]string)
+	}
+	av.PlotHands[ev.Target] = append(av.PlotHands[ev.Target], ev.Card)
+}
+
+// canTakeResponsibility returns whether anyone but the given player holds a
+// card that could be taken from them.
+func (av *Avalon) canTakeResponsibility(nick string) bool {
+	for holder, hand := range av.PlotHands {
+		if holder != nick && len(hand) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// discardStrandedResponsibility discards any Take Responsibility whose holder
+// no longer has a card to take, since it must be used before the leader
+// proposes and otherwise never could be.
+func (av *Avalon) discardStrandedResponsibility() {
+	for _, holder := range av.Seats {
+		if contains(av.PlotHands[holder], PlotTakeResponsibility) && !av.canTakeResponsibility(holder) {
+			av.PlotHands[holder] = remove(av.PlotHands[holder], PlotTakeResponsibility)
+		}
+	}
+}
+
+// TakeResponsibility uses a Take Responsibility card to take the named plot
+// card from target.
+func (av *Avalon) TakeResponsibility(holder string, target string, card string) error {
+	if err := av.expectPhase("take responsibility", PhaseProposing); err != nil {
+		return err
+	}
+
+	if !contains(av.PlotHands[holder], PlotTakeResponsibility) {
+		return ErrPlotCardNotHeld
+	}
+
+	if target == holder || !contains(av.PlotHands[target], card) {
+		return ErrPlotCardNotUsable
+	}
+
+	av.record(Event{Type: EventResponsibilityTaken, Player: holder, Target: target, Card: card})
+	return nil
+}
+
+func (av *Avalon) applyResponsibilityTaken(ev Event) {
+	av.usePlotCard(ev.Player, PlotTakeResponsibility, ev.Target).Taken = ev.Card
+	av.PlotHands[ev.Target] = remove(av.PlotHands[ev.Target], ev.Card)
+	av.PlotHands[ev.Player] = append(av.PlotHands[ev.Player], ev.Card)
+	av.discardStrandedResponsibility()
+}
+
+// UsePlotCard uses one of the holder's plot cards, other than Take
+// Responsibility, on target. Cards that need no target ignore it. Anything the
+// card reveals is returned, and should only be told to the holder, except for
+// Establish Confidence, which shows the holder's loyalty to target.
+func (av *Avalon) UsePlotCard(holder string, card string, target string) (PlotReveal, error) {
+	if !contains(av.PlotHands[holder], card) {
+		return PlotReveal{}, ErrPlotCardNotHeld
+	}
+
+	info, _ := lookupPlotCard(card)
+	if info.Permanent || card == PlotTakeResponsibility {
+		return PlotReveal{}, ErrPlotCardNotUsable
+	}
+
+	if err := av.expectPhase("use "+card, info.Phase); err != nil {
+		return PlotReveal{}, err
+	}
+
+	if !info.Immediate && av.plotCardsPending() {
+		return PlotReveal{}, ErrPlotCardsPending
+	}
+
+	if err := av.validatePlotTarget(holder, card, target); err != nil {
+		return PlotReveal{}, err
+	}
+
+	var reveal PlotReveal
+	switch card {
+	case PlotOverheardConversation:
+		reveal.Loyalty = av.LoyaltyOf(target)
+	case PlotEstablishConfidence:
+		reveal.Loyalty = av.LoyaltyOf(holder)
+	case PlotKeepingCloseWatch:
+		reveal.Success = av.questCardsPlayed[target]
+	}
+
+	av.record(Event{Type: EventPlotCardUsed, Player: holder, Target: target, Card: card})
+	return reveal, nil
+}
+
+// validatePlotTarget checks that the card can be used by holder on target
+// right now.
+func (av *Avalon) validatePlotTarget(holder string, card string, target string) error {
+	_, played := av.questCardsPlayed[target]
+
+	var usable bool
+	switch card {
+	case PlotOverheardConversation:
+		usable = target != holder && (target == av.LeftOf(holder) || target == av.RightOf(holder))
+	case PlotEstablishConfidence:
+		usable = target != holder && av.PlayerExists(target)
+	case PlotStrongLeader:
+		usable = holder != av.CurrentLeader
+	case PlotRestoreHonor:
+		usable = av.VoteTrack > 0
+	case PlotLeadToVictory:
+		usable = holder == av.CurrentLeader && len(av.CurrentVotes) == 0
+	case PlotNoConfidence:
+		usable = len(av.questCardsPlayed) == 0
+	case PlotInTheSpotlight:
+		usable = contains(av.CurrentProposedParty, target) && !played && av.currentRecord().Spotlight == ""
+	case PlotKeepingCloseWatch:
+		usable = contains(av.CurrentProposedParty, target) && played
+	}
+
+	if !usable {
+		return ErrPlotCardNotUsable
+	}
+
+	return nil
+}
+
+func (av *Avalon) applyPlotCardUsed(ev Event) {
+	av.usePlotCard(ev.Player, ev.Card, ev.Target)
+	av.discardStrandedResponsibility()
+
+	switch ev.Card {
+	case PlotStrongLeader:
+		av.CurrentLeader = ev.Player
+	case PlotRestoreHonor:
+		av.VoteTrack--
+	case PlotLeadToVictory:
+		proposal := av.currentProposal()
+		proposal.Approved = true
+		av.recordProposal(proposal)
+	case PlotNoConfidence:
+		record := av.currentRecord()
+		record.Proposals[len(record.Proposals)-1].Overturned = true
+		av.rejectParty()
+	case PlotInTheSpotlight:
+		av.currentRecord().Spotlight = ev.Target
+	}
+}
+
+// usePlotCard discards the holder's card and records its use.
+func (av *Avalon) usePlotCard(holder string, card string, target string) *PlotUse {
+	av.PlotHands[holder] = remove(av.PlotHands[holder], card)
+	av.PlotUses = append(av.PlotUses, PlotUse{
+		Quest:  av.targetQuest(),
+		Holder: holder,
+		Card:   card,
+		Target: target,
+	})
+
+	return &av.PlotUses[len(av.PlotUses)-1]
+}
@@ -0,0 +1,323 @@
+package avalon
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// newPlotAvalon starts a five player plot game that draws from the given deck.
+func newPlotAvalon(t *testing.T, deck []string) *Avalon {
+	return newPlotAvalonWith(t, []string{"A", "B", "C", "D", "E"}, deck)
+}
+
+// newPlotAvalonWith starts a plot game with the given players that draws from
+// the given deck.
+func newPlotAvalonWith(t *testing.T, players []string, deck []string) *Avalon {
+	dealt := newTestAvalon(t, players, []string{"plot"})
+	if err := dealt.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assignment := dealt.assignment()
+	assignment.PlotDeck = deck
+
+	av := newTestAvalon(t, players, []string{"plot"})
+	if err := av.Apply(Event{Type: EventRolesAssigned, Assignment: assignment, Salt: newSalt()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return av
+}
+
+// notLeader returns a player other than the current leader and the given
+// players.
+func notLeader(av *Avalon, except ...string) string {
+	for _, nick := range av.Seats {
+		if nick != av.CurrentLeader && !contains(except, nick) {
+			return nick
+		}
+	}
+
+	return ""
+}
+
+func TestNewPlotDeck(t *testing.T) {
+	var tests = []struct {
+		numPlayers int
+		size       int
+		copies     map[string]int
+	}{
+		{5, 10, map[string]int{PlotStrongLeader: 1, PlotTakeResponsibility: 1}},
+		{6, 10, map[string]int{PlotNoConfidence: 1, PlotEstablishConfidence: 1}},
+		{7, 15, map[string]int{PlotStrongLeader: 2, PlotTakeResponsibility: 1}},
+		{10, 15, map[string]int{PlotKeepingCloseWatch: 2, PlotInTheSpotlight: 1}},
+	}
+
+	for _, test := range tests {
+		deck := newPlotDeck(test.numPlayers, rand.New(rand.NewSource(1)))
+		if len(deck) != test.size {
+			t.Errorf("wanted %d cards for %d players, got %d", test.size, test.numPlayers, len(deck))
+		}
+
+		counts := make(map[string]int)
+		for _, card := range deck {
+			counts[card]++
+		}
+		for card, want := range test.copies {
+			if counts[card] != want {
+				t.Errorf("wanted %d %s for %d players, got %d", want, card, test.numPlayers, counts[card])
+			}
+		}
+	}
+}
+
+func TestDealPlotCard(t *testing.T) {
+	av := newPlotAvalon(t, []string{PlotOverheardConversation, PlotStrongLeader})
+	leader := av.CurrentLeader
+	target := notLeader(av)
+
+	if !reflect.DeepEqual(av.CurrentPlotCards, []string{PlotOverheardConversation}) {
+		t.Fatalf("expected the leader to draw one card, got %v", av.CurrentPlotCards)
+	}
+	if err := av.ProposeParty(leader, validParty(av)); err != ErrPlotCardsPending {
+		t.Errorf("wanted %v, got %v", ErrPlotCardsPending, err)
+	}
+
+	var tests = []struct {
+		leader string
+		card   string
+		target string
+		want   error
+	}{
+		{target, PlotOverheardConversation, leader, ErrNotLeader},
+		{leader, PlotStrongLeader, target, ErrPlotCardNotHeld},
+		{leader, PlotOverheardConversation, "Z", ErrPlayerNotFound},
+		{leader, PlotOverheardConversation, leader, ErrPlotCardToLeader},
+		{leader, PlotOverheardConversation, target, nil},
+	}
+
+	for _, test := range tests {
+		if err := av.DealPlotCard(test.leader, test.card, test.target); err != test.want {
+			t.Errorf("dealing %s from %s to %s, wanted %v, got %v", test.card, test.leader, test.target, test.want, err)
+		}
+	}
+
+	// Immediate cards must be used before the leader proposes
+	if err := av.ProposeParty(leader, validParty(av)); err != ErrPlotCardsPending {
+		t.Errorf("wanted %v, got %v", ErrPlotCardsPending, err)
+	}
+
+	far := notLeader(av, target, av.LeftOf(target), av.RightOf(target))
+	if _, err := av.UsePlotCard(target, PlotOverheardConversation, far); err != ErrPlotCardNotUsable {
+		t.Errorf("wanted %v overhearing %s, got %v", ErrPlotCardNotUsable, far, err)
+	}
+
+	reveal, err := av.UsePlotCard(target, PlotOverheardConversation, av.LeftOf(target))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reveal.Loyalty != av.LoyaltyOf(av.LeftOf(target)) {
+		t.Errorf("expected to overhear %s, got %s", av.LoyaltyOf(av.LeftOf(target)), reveal.Loyalty)
+	}
+
+	if err := av.ProposeParty(leader, validParty(av)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(av.PlotCardsOf(target)) != 0 || len(av.PlotUses) != 1 {
+		t.Errorf("expected the card to be used up, got %v and %v", av.PlotCardsOf(target), av.PlotUses)
+	}
+}
+
+func TestTakeResponsibility(t *testing.T) {
+	// With nothing to take, Take Responsibility is discarded
+	av := newPlotAvalon(t, []string{PlotTakeResponsibility})
+	if err := av.DealPlotCard(av.CurrentLeader, PlotTakeResponsibility, notLeader(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	av = newPlotAvalon(t, []string{PlotOpinionMaker, PlotTakeResponsibility})
+	maker := notLeader(av)
+	if err := av.DealPlotCard(av.CurrentLeader, PlotOpinionMaker, maker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := notLeader(av, maker)
+	if err := av.Vote(other, false); err != ErrOpinionMakerFirst {
+		t.Errorf("wanted %v, got %v", ErrOpinionMakerFirst, err)
+	}
+	for _, nick := range append([]string{maker}, remove(copyStrings(av.Players), maker)...) {
+		if err := av.Vote(nick, false); err != nil {
+			t.Fatalf("unexpected error voting: %v", err)
+		}
+	}
+
+	taker := notLeader(av, maker)
+	if err := av.DealPlotCard(av.CurrentLeader, PlotTakeResponsibility, taker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.TakeResponsibility(taker, maker, PlotStrongLeader); err != ErrPlotCardNotUsable {
+		t.Errorf("wanted %v, got %v", ErrPlotCardNotUsable, err)
+	}
+	if err := av.TakeResponsibility(taker, maker, PlotOpinionMaker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(av.PlotCardsOf(taker), []string{PlotOpinionMaker}) || len(av.PlotCardsOf(maker)) != 0 {
+		t.Errorf("expected %s to hold opinion maker, got %v", taker, av.PlotHands)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
+
+func TestStrandedResponsibility(t *testing.T) {
+	// Take Responsibility is dealt while there is a card to take, but that
+	// card is used before it can be taken
+	av := newPlotAvalonWith(t, []string{"A", "B", "C", "D", "E", "F", "G"},
+		[]string{PlotOverheardConversation, PlotTakeResponsibility})
+	overhearer := notLeader(av)
+	taker := notLeader(av, overhearer)
+
+	if err := av.DealPlotCard(av.CurrentLeader, PlotOverheardConversation, overhearer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.DealPlotCard(av.CurrentLeader, PlotTakeResponsibility, taker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := av.UsePlotCard(overhearer, PlotOverheardConversation, av.LeftOf(overhearer)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(av.PlotCardsOf(taker)) != 0 {
+		t.Errorf("expected take responsibility to be discarded, got %v", av.PlotCardsOf(taker))
+	}
+	if err := av.ProposeParty(av.CurrentLeader, validParty(av)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
+
+func TestProposingPlotCards(t *testing.T) {
+	av := newPlotAvalon(t, nil)
+	holder := notLeader(av)
+	av.PlotHands = map[string][]string{
+		holder:           {PlotStrongLeader, PlotRestoreHonor},
+		av.CurrentLeader: {PlotStrongLeader},
+	}
+
+	if _, err := av.UsePlotCard(av.CurrentLeader, PlotStrongLeader, ""); err != ErrPlotCardNotUsable {
+		t.Errorf("wanted %v for the leader, got %v", ErrPlotCardNotUsable, err)
+	}
+	if _, err := av.UsePlotCard(holder, PlotRestoreHonor, ""); err != ErrPlotCardNotUsable {
+		t.Errorf("wanted %v with an empty vote track, got %v", ErrPlotCardNotUsable, err)
+	}
+	if _, err := av.UsePlotCard(holder, PlotStrongLeader, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.CurrentLeader != holder {
+		t.Errorf("expected %s to take the lead, got %s", holder, av.CurrentLeader)
+	}
+
+	if err := av.ProposeParty(holder, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, false)
+	if _, err := av.UsePlotCard(holder, PlotRestoreHonor, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.VoteTrack != 0 || av.CurrentLeader != av.LeftOf(holder) {
+		t.Errorf("expected vote track 0 with %s leading, got %d with %s", av.LeftOf(holder), av.VoteTrack, av.CurrentLeader)
+	}
+}
+
+func TestVotingPlotCards(t *testing.T) {
+	av := newPlotAvalon(t, nil)
+	leader := av.CurrentLeader
+	av.PlotHands = map[string][]string{leader: {PlotLeadToVictory}}
+
+	if _, err := av.UsePlotCard(leader, PlotLeadToVictory, ""); err == nil {
+		t.Error("expected an error using lead to victory before proposing")
+	}
+
+	if err := av.ProposeParty(leader, validParty(av)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := av.UsePlotCard(leader, PlotLeadToVictory, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	proposal := av.Proposals()[0]
+	if av.Phase != PhaseQuesting || !proposal.Approved || len(proposal.Votes) != 0 {
+		t.Errorf("expected the party sent without a vote, got %s with %+v", av.Phase, proposal)
+	}
+}
+
+func TestQuestingPlotCards(t *testing.T) {
+	av := newPlotAvalon(t, nil)
+	party := validParty(av)
+	holder := party[0]
+	av.PlotHands = map[string][]string{
+		holder: {PlotNoConfidence, PlotInTheSpotlight, PlotKeepingCloseWatch},
+	}
+
+	if err := av.ProposeParty(av.CurrentLeader, party); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	if _, err := av.UsePlotCard(holder, PlotNoConfidence, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !av.Proposals()[0].Overturned || av.VoteTrack != 1 || av.Phase != PhaseProposing {
+		t.Errorf("expected the approved party rejected, got %+v with vote track %d", av.Proposals()[0], av.VoteTrack)
+	}
+
+	if err := av.ProposeParty(av.CurrentLeader, party); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	if _, err := av.UsePlotCard(holder, PlotKeepingCloseWatch, party[1]); err != ErrPlotCardNotUsable {
+		t.Errorf("wanted %v before %s played, got %v", ErrPlotCardNotUsable, party[1], err)
+	}
+	if _, err := av.UsePlotCard(holder, PlotInTheSpotlight, party[1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := av.PlayQuestCard(party[0], true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reveal, err := av.UsePlotCard(holder, PlotKeepingCloseWatch, party[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reveal.Success {
+		t.Errorf("expected to see %s's success card", party[0])
+	}
+
+	if err := av.PlayQuestCard(party[1], true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record := av.QuestHistory[0]
+	if record.Spotlight != party[1] || !record.SpotlightSuccess {
+		t.Errorf("expected %s's success in the spotlight, got %+v", party[1], record)
+	}
+}
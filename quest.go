@@ -0,0 +1,178 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotInParty indicates that a player who is not on the current quest
+	// tried to play a quest card.
+	ErrNotInParty = errors.New("avalon: player is not on this quest")
+
+	// ErrAlreadyPlayed indicates that a player tried to play a second quest
+	// card on the same quest.
+	ErrAlreadyPlayed = errors.New("avalon: player has already played a quest card")
+
+	// ErrGoodMustSucceed indicates that a good player tried to play a fail
+	// card.
+	ErrGoodMustSucceed = errors.New("avalon: good players must play success")
+
+	// ErrMustFail indicates that a player whose role must fail, like the
+	// Lunatic, tried to play a success card.
+	ErrMustFail = errors.New("avalon: player must play fail")
+
+	// ErrFailNotAllowed indicates that a player whose role may only fail
+	// early quests, like the Brute, tried to fail a later one.
+	ErrFailNotAllowed = errors.New("avalon: player may not fail this quest")
+)
+
+// FailsRequired returns the number of fail cards needed for the given quest
+// (zero-indexed) to fail. Quest 4 needs two fails with 7+ players unless the
+// singlefail option is enabled.
+func (av *Avalon) FailsRequired(quest int) int {
+	if av.IsOptionEnabled("singlefail") {
+		return 1
+	}
+
+	required, ok := numPlayersToFailsRequired[av.NumPlayers()]
+	if !ok || quest < 0 || quest >= len(required) {
+		return 1
+	}
+
+	return required[quest]
+}
+
+// PlayQuestCard records a party member's success or fail card for the
+// current quest. Good players may only play success, and some evil roles are
+// limited in what they may play. Once every member of the party has played,
+// the quest is resolved, unless someone holds Excalibur and may still use it.
+func (av *Avalon) PlayQuestCard(nick string, success bool) error {
+	if err := av.expectPhase("play a quest card", PhaseQuesting); err != nil {
+		return err
+	}
+
+	if !contains(av.CurrentProposedParty, nick) {
+		return ErrNotInParty
+	}
+
+	if _, ok := av.questCardsPlayed[nick]; ok {
+		return ErrAlreadyPlayed
+	}
+
+	if err := av.validateQuestCard(nick, success); err != nil {
+		return err
+	}
+
+	av.record(Event{Type: EventQuestCardPlayed, Player: nick, Success: success})
+	return nil
+}
+
+func (av *Avalon) validateQuestCard(nick string, success bool) error {
+	if !success && av.IsGood(nick) {
+		return ErrGoodMustSucceed
+	}
+
+	if !av.IsEvil(nick) {
+		return nil
+	}
+
+	role, _ := LookupRole(av.RoleName(nick))
+	switch {
+	case success && role.MustFail:
+		return ErrMustFail
+	case !success && role.FailsUntil > 0 && av.CurrentQuest >= role.FailsUntil:
+		return ErrFailNotAllowed
+	}
+
+	return nil
+}
+
+func (av *Avalon) applyQuestCardPlayed(ev Event) {
+	if av.questCardsPlayed == nil {
+		av.questCardsPlayed = make(map[string]bool)
+	}
+	av.questCardsPlayed[ev.Player] = ev.Success
+	if !ev.Success {
+		av.questFailsPlayed++
+	}
+	if record := av.currentRecord(); record.Spotlight == ev.Player {
+		record.SpotlightSuccess = ev.Success
+	}
+
+	if len(av.questCardsPlayed) < len(av.CurrentProposedParty) {
+		return
+	}
+
+	if av.CurrentExcalibur != "" {
+		av.Phase = PhaseExcalibur
+		return
+	}
+
+	av.resolveQuest()
+}
+
+// NumQuestCardsPlayed returns how many members of the current party have
+// played their card, without revealing who played what.
+func (av *Avalon) NumQuestCardsPlayed() int {
+	return len(av.questCardsPlayed)
+}
+
+// resolveQuest records the outcome of the current quest and moves the game
+// on to whatever comes next: another proposal, the Lady of the Lake, the
+// assassination or the end of the game. In resistance mode there is no
+// assassination and three successes end the game, and with the hunter option
+// the hunters take over once either side has three quests.
+func (av *Avalon) resolveQuest() {
+	success := av.questFailsPlayed < av.FailsRequired(av.targetQuest())
+
+	record := av.currentRecord()
+	record.Quest = av.targetQuest()
+	record.Party = av.CurrentProposedParty
+	record.Excalibur = av.CurrentExcalibur
+	record.Fails = av.questFailsPlayed
+	record.ChiefCards = av.numChiefCards(av.CurrentProposedParty)
+	record.Success = success
+	record.Resolved = true
+
+	av.questCardsPlayed = nil
+	av.questFailsPlayed = 0
+
+	av.CurrentQuest++
+	av.CurrentProposedParty = nil
+	av.CurrentExcalibur = ""
+	av.VoteTrack = 0
+
+	switch {
+	case av.IsOptionEnabled("hunter") && (av.NumFails() >= 3 || av.NumSuccesses() >= 3):
+		av.Phase = PhaseHunt
+	case av.NumFails() >= 3:
+		av.Phase = PhaseGameOver
+	case av.NumSuccesses() >= 3 && av.IsOptionEnabled("resistance"):
+		av.Phase = PhaseGameOver
+	case av.NumSuccesses() >= 3:
+		av.Phase = PhaseAssassination
+	case av.IsOptionEnabled("hunter") && record.ChiefCards > 0:
+		av.CurrentInvestigator = record.Proposals[len(record.Proposals)-1].Leader
+		av.Phase = PhaseInvestigation
+	default:
+		av.continueQuests()
+	}
+}
+
+// continueQuests moves on to the Lady of the Lake, if she is used after the
+// quest just resolved, or straight to the next quest.
+func (av *Avalon) continueQuests() {
+	if av.IsOptionEnabled("lake") && av.CurrentQuest >= 2 && av.CurrentQuest <= 4 {
+		av.Phase = PhaseLadyOfTheLake
+		return
+	}
+
+	av.startQuest()
+}
+
+// startQuest starts the first round of proposals for the next quest, flipping
+// the next Lancelot loyalty card first if one is due.
+func (av *Avalon) startQuest() {
+	av.flipLancelotCard()
+	av.startRound()
+}
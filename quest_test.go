@@ -0,0 +1,166 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newQuestingAvalon(players []string, party []string) *Avalon {
+	avalon := NewAvalon()
+	avalon.Players = players
+	avalon.Goods = players[:len(players)-numEvils(len(players))]
+	avalon.Evils = players[len(players)-numEvils(len(players)):]
+	avalon.Phase = PhaseQuesting
+	avalon.CurrentLeader = players[0]
+	avalon.CurrentProposedParty = party
+
+	return avalon
+}
+
+func TestFailsRequired(t *testing.T) {
+	var tests = []struct {
+		numPlayers int
+		options    []string
+		quest      int
+		want       int
+	}{
+		{5, nil, 3, 1},
+		{6, nil, 3, 1},
+		{7, nil, 3, 2},
+		{7, nil, 4, 1},
+		{10, nil, 3, 2},
+		{10, []string{"singlefail"}, 3, 1},
+		{10, nil, 7, 1},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = make([]string, test.numPlayers)
+		avalon.EnableMany(test.options)
+
+		n := avalon.FailsRequired(test.quest)
+		if n != test.want {
+			t.Errorf("wanted %d for quest %d with %d players and %v, got %d",
+				test.want, test.quest, test.numPlayers, test.options, n)
+		}
+	}
+}
+
+func TestPlayQuestCardErrors(t *testing.T) {
+	// Goods: A, B, C; Evils: D, E
+	avalon := newQuestingAvalon([]string{"A", "B", "C", "D", "E"}, []string{"A", "D"})
+
+	if err := avalon.PlayQuestCard("B", true); err != ErrNotInParty {
+		t.Errorf("wanted %v, got %v", ErrNotInParty, err)
+	}
+	if err := avalon.PlayQuestCard("A", false); err != ErrGoodMustSucceed {
+		t.Errorf("wanted %v, got %v", ErrGoodMustSucceed, err)
+	}
+	if err := avalon.PlayQuestCard("A", true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := avalon.PlayQuestCard("A", true); err != ErrAlreadyPlayed {
+		t.Errorf("wanted %v, got %v", ErrAlreadyPlayed, err)
+	}
+	if n := avalon.NumQuestCardsPlayed(); n != 1 {
+		t.Errorf("expected 1 card played, got %d", n)
+	}
+}
+
+func TestPlayQuestCard(t *testing.T) {
+	var tests = []struct {
+		players     []string
+		quest       int
+		party       []string
+		cards       []bool
+		wantSuccess bool
+		wantFails   int
+	}{
+		// Goods: A, B, C; Evils: D, E
+		{
+			[]string{"A", "B", "C", "D", "E"}, 0,
+			[]string{"A", "B"}, []bool{true, true},
+			true, 0,
+		},
+		{
+			[]string{"A", "B", "C", "D", "E"}, 0,
+			[]string{"A", "D"}, []bool{true, false},
+			false, 1,
+		},
+		{
+			[]string{"A", "B", "C", "D", "E"}, 3,
+			[]string{"A", "D", "E"}, []bool{true, false, true},
+			false, 1,
+		},
+		// Goods: A, B, C, D; Evils: E, F, G
+		{
+			[]string{"A", "B", "C", "D", "E", "F", "G"}, 3,
+			[]string{"A", "B", "C", "E"}, []bool{true, true, true, false},
+			true, 1,
+		},
+		{
+			[]string{"A", "B", "C", "D", "E", "F", "G"}, 3,
+			[]string{"A", "B", "E", "F"}, []bool{true, true, false, false},
+			false, 2,
+		},
+	}
+
+	for _, test := range tests {
+		avalon := newQuestingAvalon(test.players, test.party)
+		avalon.CurrentQuest = test.quest
+		avalon.QuestHistory = make([]QuestRecord, test.quest)
+
+		for i, nick := range test.party {
+			if err := avalon.PlayQuestCard(nick, test.cards[i]); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if avalon.CurrentQuest != test.quest+1 {
+			t.Errorf("expected quest to advance to %d, got %d", test.quest+1, avalon.CurrentQuest)
+		}
+		record := avalon.QuestHistory[test.quest]
+		if !record.Resolved || record.Quest != test.quest {
+			t.Errorf("expected quest %d to be resolved, got %+v", test.quest, record)
+		}
+		if record.Success != test.wantSuccess {
+			t.Errorf("wanted success %t for %v on quest %d, got %t", test.wantSuccess, test.cards, test.quest, record.Success)
+		}
+		if record.Fails != test.wantFails {
+			t.Errorf("wanted %d fails for %v, got %d", test.wantFails, test.cards, record.Fails)
+		}
+		if !reflect.DeepEqual(record.Party, test.party) {
+			t.Errorf("wanted party %v recorded, got %v", test.party, record.Party)
+		}
+		if n := avalon.NumQuestCardsPlayed(); n != 0 {
+			t.Errorf("expected played cards to reset, got %d", n)
+		}
+	}
+}
+
+func TestRestrictedQuestCards(t *testing.T) {
+	var tests = []struct {
+		special string
+		quest   int
+		success bool
+		want    error
+	}{
+		{"lunatic", 0, true, ErrMustFail},
+		{"lunatic", 0, false, nil},
+		{"brute", 2, false, nil},
+		{"brute", 3, false, ErrFailNotAllowed},
+		{"brute", 3, true, nil},
+		{"assassin", 4, false, nil},
+	}
+
+	for _, test := range tests {
+		// Goods: A, B, C; Evils: D, E
+		avalon := newQuestingAvalon([]string{"A", "B", "C", "D", "E"}, []string{"A", "D"})
+		avalon.Specials = map[string]string{test.special: "D"}
+		avalon.CurrentQuest = test.quest
+
+		if err := avalon.PlayQuestCard("D", test.success); err != test.want {
+			t.Errorf("%s on quest %d playing %t, wanted %v, got %v", test.special, test.quest, test.success, test.want, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package avalon
+
+import (
+	"fmt"
+)
+
+// With the resistance option, the game is plain The Resistance: there is no
+// Merlin or Assassin, the spies know each other and the resistance wins as
+// soon as three missions succeed.
+
+var resistanceSideNames = map[Loyalty]string{
+	LoyaltyGood: "resistance",
+	LoyaltyEvil: "spies",
+}
+
+// What the winners are called in a result, since the spies are plural.
+var resistanceWinners = map[Loyalty]string{
+	LoyaltyGood: "the resistance wins",
+	LoyaltyEvil: "the spies win",
+}
+
+var resistanceReasonDescriptions = map[WinReason]string{
+	ReasonThreeFails:     "three missions failed",
+	ReasonFiveRejections: "five teams were rejected in a row",
+	ReasonThreeSuccesses: "three missions succeeded",
+}
+
+var resistanceFlavorText = map[string]string{
+	roleGood: "You are a member of the Resistance. Succeed three missions to win.",
+	roleEvil: "You are a spy and know the other spies. Fail three missions to win.",
+}
+
+// SideName returns what the given side is called in a game with this config:
+// "resistance" and "spies" in resistance mode, otherwise "good" and "evil".
+func (ac *AvalonConfig) SideName(loyalty Loyalty) string {
+	if name, ok := resistanceSideNames[loyalty]; ok && ac.IsOptionEnabled("resistance") {
+		return name
+	}
+
+	return loyalty.String()
+}
+
+// FlavorTextFor returns the flavor text for the given role, including the
+// plain "good" and "evil" roles, in a game with this config.
+func (ac *AvalonConfig) FlavorTextFor(role string) string {
+	if text, ok := resistanceFlavorText[role]; ok && ac.IsOptionEnabled("resistance") {
+		return text
+	}
+
+	return FlavorTextForSpecial(role)
+}
+
+// ReasonText describes how a game was won in a game with this config, with
+// missions and teams rather than quests and parties in resistance mode.
+func (ac *AvalonConfig) ReasonText(reason WinReason) string {
+	if desc, ok := resistanceReasonDescriptions[reason]; ok && ac.IsOptionEnabled("resistance") {
+		return desc
+	}
+
+	return reason.String()
+}
+
+// ResultText returns a human-readable summary of the result in this game's
+// terms, like Result.String but with "the spies win: three missions failed"
+// in resistance mode. Errors if the game is not over yet.
+func (av *Avalon) ResultText() (string, error) {
+	result, err := av.Result()
+	if err != nil {
+		return "", err
+	}
+
+	if !av.IsOptionEnabled("resistance") {
+		return result.String(), nil
+	}
+
+	return fmt.Sprintf("%s: %s", resistanceWinners[result.Winner], av.ReasonText(result.Reason)), nil
+}
@@ -0,0 +1,93 @@
+package avalon
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestResistanceGame(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"resistance"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(av.Specials) != 0 {
+		t.Errorf("expected no special characters, got %v", av.Specials)
+	}
+
+	for _, spy := range av.Evils {
+		others := remove(copyStrings(av.Evils), spy)
+		sort.Strings(others)
+
+		view := av.KnowledgeFor(spy)
+		if !reflect.DeepEqual(view.KnownEvils, others) {
+			t.Errorf("expected %s to know the other spies, got %v", spy, view.KnownEvils)
+		}
+	}
+	if view := av.KnowledgeFor(av.Goods[0]); view.KnownEvils != nil || view.Candidates != nil {
+		t.Errorf("expected the resistance to know nothing, got %+v", view)
+	}
+
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	playQuest(t, av, true)
+	playQuest(t, av, false)
+	playQuest(t, av, true)
+	playQuest(t, av, true)
+	if av.Phase != PhaseGameOver {
+		t.Fatalf("expected %s after three successes, got %s", PhaseGameOver, av.Phase)
+	}
+
+	result, err := av.Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Result{LoyaltyGood, ReasonThreeSuccesses}); result != want {
+		t.Errorf("wanted %s, got %s", want, result)
+	}
+
+	text, err := av.ResultText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "the resistance wins: three missions succeeded"; text != want {
+		t.Errorf("wanted %q, got %q", want, text)
+	}
+}
+
+func TestResistanceConfig(t *testing.T) {
+	config := NewAvalonConfig()
+	config.EnableMany([]string{"resistance", "morganapercival", "lake"})
+	if err := config.IsValid(7); err == nil || err.Error() != "resistance cannot be played with morganapercival" {
+		t.Errorf("expected resistance to rule out morganapercival, got %v", err)
+	}
+
+	var tests = []struct {
+		options []string
+		side    string
+		text    string
+		reason  string
+	}{
+		{nil, "evil", "", "three quests failed"},
+		{[]string{"resistance"}, "spies", resistanceFlavorText[roleEvil], "three missions failed"},
+	}
+
+	for _, test := range tests {
+		config := NewAvalonConfig()
+		config.EnableMany(test.options)
+		if side := config.SideName(LoyaltyEvil); side != test.side {
+			t.Errorf("with %v, wanted %s, got %s", test.options, test.side, side)
+		}
+		if text := config.FlavorTextFor(roleEvil); text != test.text {
+			t.Errorf("with %v, wanted %q, got %q", test.options, test.text, text)
+		}
+		if reason := config.ReasonText(ReasonThreeFails); reason != test.reason {
+			t.Errorf("with %v, wanted %q, got %q", test.options, test.reason, reason)
+		}
+	}
+
+	if text := config.FlavorTextFor("merlin"); text != FlavorTextForSpecial("merlin") {
+		t.Errorf("expected Merlin's usual flavor text, got %q", text)
+	}
+}
@@ -0,0 +1,112 @@
+package avalon
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrGameNotOver indicates that the result of a game was requested before
+	// the game ended.
+	ErrGameNotOver = errors.New("avalon: the game is not over")
+)
+
+// WinReason describes how a game was won.
+type WinReason int
+
+// Ways a game of Avalon can end.
+const (
+	ReasonThreeFails WinReason = iota
+	ReasonFiveRejections
+	ReasonMerlinAssassinated
+	ReasonMerlinSurvived
+	ReasonThreeSuccesses
+	ReasonChiefFound
+	ReasonChiefEscaped
+)
+
+var winReasonDescriptions = map[WinReason]string{
+	ReasonThreeFails:         "three quests failed",
+	ReasonFiveRejections:     "five parties were rejected in a row",
+	ReasonMerlinAssassinated: "the assassin found Merlin",
+	ReasonMerlinSurvived:     "Merlin survived the assassination",
+	ReasonThreeSuccesses:     "three missions succeeded",
+	ReasonChiefFound:         "the hunter found the chief",
+	ReasonChiefEscaped:       "the chief escaped the hunter",
+}
+
+// String returns a human-readable description of the reason.
+func (r WinReason) String() string {
+	desc, ok := winReasonDescriptions[r]
+	if !ok {
+		return fmt.Sprintf("reason(%d)", int(r))
+	}
+
+	return desc
+}
+
+// Result is the outcome of a finished game.
+type Result struct {
+	Winner Loyalty
+	Reason WinReason
+}
+
+// String returns a human-readable summary of the result.
+// Example: "evil wins: three quests failed"
+func (r Result) String() string {
+	return fmt.Sprintf("%s wins: %s", r.Winner, r.Reason)
+}
+
+// Result reports which side won the game and why. Errors if the game is not
+// over yet.
+func (av *Avalon) Result() (Result, error) {
+	if av.Phase != PhaseGameOver {
+		return Result{}, ErrGameNotOver
+	}
+
+	switch {
+	case av.VoteTrack >= MaxRejections:
+		return Result{LoyaltyEvil, ReasonFiveRejections}, nil
+	case av.Accuser != "":
+		return av.accusationResult(), nil
+	case av.NumFails() >= 3:
+		return Result{LoyaltyEvil, ReasonThreeFails}, nil
+	case av.IsOptionEnabled("resistance"):
+		return Result{LoyaltyGood, ReasonThreeSuccesses}, nil
+	case av.AssassinationTarget == av.Specials["merlin"]:
+		return Result{LoyaltyEvil, ReasonMerlinAssassinated}, nil
+	default:
+		return Result{LoyaltyGood, ReasonMerlinSurvived}, nil
+	}
+}
+
+// Winners returns every player on the winning side at the end of the game.
+// Since the Lancelots may have switched, that is the side each player ended
+// the game on rather than the one they were dealt. Errors if the game is not
+// over yet.
+func (av *Avalon) Winners() ([]string, error) {
+	winner, err := av.Winner()
+	if err != nil {
+		return nil, err
+	}
+
+	var winners []string
+	for _, nick := range av.Seats {
+		if av.LoyaltyOf(nick) == winner {
+			winners = append(winners, nick)
+		}
+	}
+
+	return winners, nil
+}
+
+// Winner returns the side that won the game. Errors if the game is not over
+// yet.
+func (av *Avalon) Winner() (Loyalty, error) {
+	result, err := av.Result()
+	if err != nil {
+		return LoyaltyUnknown, err
+	}
+
+	return result.Winner, nil
+}
@@ -0,0 +1,75 @@
+package avalon
+
+import (
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	var tests = []struct {
+		successes []bool
+		voteTrack int
+		target    string
+		want      Result
+	}{
+		{
+			[]bool{false, true, false, false}, 0, "",
+			Result{LoyaltyEvil, ReasonThreeFails},
+		},
+		{
+			[]bool{true, false}, MaxRejections, "",
+			Result{LoyaltyEvil, ReasonFiveRejections},
+		},
+		{
+			[]bool{true, true, false, true}, 0, "A",
+			Result{LoyaltyEvil, ReasonMerlinAssassinated},
+		},
+		{
+			[]bool{true, true, true}, 0, "B",
+			Result{LoyaltyGood, ReasonMerlinSurvived},
+		},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = []string{"A", "B", "C", "D", "E"}
+		avalon.Specials = map[string]string{"merlin": "A", "assassin": "E"}
+		avalon.Phase = PhaseGameOver
+		for i, success := range test.successes {
+			avalon.QuestHistory = append(avalon.QuestHistory, QuestRecord{Quest: i, Success: success, Resolved: true})
+		}
+		avalon.VoteTrack = test.voteTrack
+		avalon.AssassinationTarget = test.target
+
+		result, err := avalon.Result()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != test.want {
+			t.Errorf("wanted %s, got %s", test.want, result)
+		}
+
+		winner, _ := avalon.Winner()
+		if winner != test.want.Winner {
+			t.Errorf("wanted winner %s, got %s", test.want.Winner, winner)
+		}
+	}
+}
+
+func TestResultBeforeGameOver(t *testing.T) {
+	avalon := NewAvalon()
+	avalon.Phase = PhaseAssassination
+
+	if _, err := avalon.Result(); err != ErrGameNotOver {
+		t.Errorf("wanted %v, got %v", ErrGameNotOver, err)
+	}
+	if winner, err := avalon.Winner(); err != ErrGameNotOver || winner != LoyaltyUnknown {
+		t.Errorf("wanted %s and %v, got %s and %v", LoyaltyUnknown, ErrGameNotOver, winner, err)
+	}
+}
+
+func TestResultString(t *testing.T) {
+	result := Result{LoyaltyEvil, ReasonThreeFails}
+	if s := result.String(); s != "evil wins: three quests failed" {
+		t.Errorf("unexpected result string: %s", s)
+	}
+}
@@ -0,0 +1,331 @@
+package avalon
+
+// Role describes a character a player can be dealt. Validation, assignment and
+// night knowledge are all derived from the registered roles, so adding a role
+// only takes a new entry in registeredRoles.
+type Role struct {
+	Name    string
+	Loyalty Loyalty
+
+	// The option that puts the role in the game, or "" if it is in every
+	// game, the fewest players the role can be played with and options the
+	// role is pointless without, at least one of which must be enabled too.
+	Option     string
+	MinPlayers int
+	Requires   []string
+
+	// Sees lists what the role learns at night: other roles by name, or
+	// "good" or "evil" for every player on that side. HiddenFrom lists roles,
+	// or "good" or "evil" for a whole side, that do not see this role even
+	// when they otherwise would. Anyone who sees the AppearsAs role also
+	// sees this one in its place. SeesWith adds to Sees while an option is
+	// enabled.
+	Sees       []string
+	HiddenFrom []string
+	AppearsAs  string
+	SeesWith   map[string][]string
+
+	// SeesFirstLeader roles learn the loyalty of the first leader at night.
+	// InvestigatedAs is the loyalty the role shows when investigated, if not
+	// its own. MayLie roles choose which loyalty to show each time they are
+	// investigated instead. RevealedAfterFails roles are revealed to everyone once that
+	// many quests have failed.
+	SeesFirstLeader    bool
+	InvestigatedAs     Loyalty
+	MayLie             bool
+	RevealedAfterFails int
+
+	// MustFail roles may only play fail cards. Roles with FailsUntil may only
+	// play fail cards before that many quests have been attempted.
+	MustFail   bool
+	FailsUntil int
+
+	// Resistance roles come from The Resistance rather than Avalon and are
+	// the only ones allowed in resistance mode. With the hunter option, each
+	// side's Chief is hunted by the other side's Hunter, and anyone who
+	// PlaysChiefCards marks the quests they go on.
+	Resistance      bool
+	Chief           bool
+	Hunter          bool
+	PlaysChiefCards bool
+
+	FlavorText string
+}
+
+// Names of the roles given to players without a special character.
+const (
+	roleGood = "good"
+	roleEvil = "evil"
+)
+
+var (
+	goodRole = Role{Name: roleGood, Loyalty: LoyaltyGood}
+	evilRole = Role{Name: roleEvil, Loyalty: LoyaltyEvil, Sees: []string{roleEvil}}
+
+	// Special characters in the order they are assigned.
+	registeredRoles = []Role{
+		{
+			Name:       "merlin",
+			Loyalty:    LoyaltyGood,
+			Sees:       []string{roleEvil},
+			FlavorText: "You see all evils except Mordred. You must keep yourself hidden from Assassin.",
+		},
+		{
+			Name:       "percival",
+			Loyalty:    LoyaltyGood,
+			Option:     "morganapercival",
+			Sees:       []string{"merlin"},
+			FlavorText: "You see Merlin's identity, but Morgana attempts to trick you.",
+		},
+		{
+			Name:       "assassin",
+			Loyalty:    LoyaltyEvil,
+			Sees:       []string{roleEvil},
+			FlavorText: "You are on the prowl for Merlin. If he reveals himself, you will kill him.",
+		},
+		{
+			Name:       "mordred",
+			Loyalty:    LoyaltyEvil,
+			Option:     "mordred",
+			Sees:       []string{roleEvil},
+			HiddenFrom: []string{"merlin"},
+			FlavorText: "You remain unknown to Merlin.",
+		},
+		{
+			Name:       "morgana",
+			Loyalty:    LoyaltyEvil,
+			Option:     "morganapercival",
+			Sees:       []string{roleEvil},
+			AppearsAs:  "merlin",
+			FlavorText: "You appear as Merlin to Percival.",
+		},
+		{
+			Name:       "oberon",
+			Loyalty:    LoyaltyEvil,
+			Option:     "oberon",
+			MinPlayers: 10,
+			HiddenFrom: []string{roleEvil},
+			FlavorText: "You are unknown to the other evils and you do not know them.",
+		},
+		{
+			Name:       "goodlancelot",
+			Loyalty:    LoyaltyGood,
+			Option:     "lancelot",
+			SeesWith:   map[string][]string{"lancelotsknow": {"evillancelot"}},
+			FlavorText: "You serve Arthur, but the loyalty deck may turn you to evil.",
+		},
+		{
+			Name:       "evillancelot",
+			Loyalty:    LoyaltyEvil,
+			Option:     "lancelot",
+			Sees:       []string{roleEvil},
+			SeesWith:   map[string][]string{"lancelotsknow": {"goodlancelot"}},
+			FlavorText: "You serve Mordred, but the loyalty deck may turn you to good.",
+		},
+		{
+			Name:            "cleric",
+			Loyalty:         LoyaltyGood,
+			Option:          "cleric",
+			SeesFirstLeader: true,
+			FlavorText:      "You learn the loyalty of the first leader.",
+		},
+		{
+			// Sometimes called the Apprentice.
+			Name:           "troublemaker",
+			Loyalty:        LoyaltyGood,
+			Option:         "troublemaker",
+			Requires:       []string{"lake", "cleric"},
+			InvestigatedAs: LoyaltyEvil,
+			FlavorText:     "You must lie about your loyalty when investigated.",
+		},
+		{
+			Name:       "untrustworthyservant",
+			Loyalty:    LoyaltyGood,
+			Option:     "untrustworthyservant",
+			Sees:       []string{"assassin"},
+			AppearsAs:  roleEvil,
+			HiddenFrom: []string{roleEvil},
+			FlavorText: "You appear evil to Merlin and know the Assassin. If you tell the Assassin who you are, you join evil.",
+		},
+		{
+			Name:       "trickster",
+			Loyalty:    LoyaltyEvil,
+			Option:     "trickster",
+			Requires:   []string{"lake", "cleric"},
+			Sees:       []string{roleEvil},
+			MayLie:     true,
+			FlavorText: "You may lie about your loyalty when investigated.",
+		},
+		{
+			Name:       "lunatic",
+			Loyalty:    LoyaltyEvil,
+			Option:     "lunatic",
+			Sees:       []string{roleEvil},
+			MustFail:   true,
+			FlavorText: "You must fail every quest you go on.",
+		},
+		{
+			Name:       "brute",
+			Loyalty:    LoyaltyEvil,
+			Option:     "brute",
+			Sees:       []string{roleEvil},
+			FailsUntil: 3,
+			FlavorText: "You may only fail the first three quests.",
+		},
+		{
+			Name:               "revealer",
+			Loyalty:            LoyaltyEvil,
+			Option:             "revealer",
+			Sees:               []string{roleEvil},
+			RevealedAfterFails: 2,
+			FlavorText:         "You are revealed to everyone once two quests have failed.",
+		},
+		{
+			Name:            "resistancechief",
+			Loyalty:         LoyaltyGood,
+			Option:          "hunter",
+			Requires:        []string{"resistance"},
+			Resistance:      true,
+			Chief:           true,
+			PlaysChiefCards: true,
+			FlavorText:      "You lead the Resistance. Stay hidden from the Spy Hunter.",
+		},
+		{
+			Name:       "resistancehunter",
+			Loyalty:    LoyaltyGood,
+			Option:     "hunter",
+			Requires:   []string{"resistance"},
+			Resistance: true,
+			Hunter:     true,
+			FlavorText: "Find the Spy Chief. Accuse them correctly and the Resistance wins.",
+		},
+		{
+			Name:            "spychief",
+			Loyalty:         LoyaltyEvil,
+			Option:          "hunter",
+			Requires:        []string{"resistance"},
+			Resistance:      true,
+			Sees:            []string{roleEvil},
+			Chief:           true,
+			PlaysChiefCards: true,
+			FlavorText:      "You lead the spies. Stay hidden from the Resistance Hunter.",
+		},
+		{
+			Name:       "spyhunter",
+			Loyalty:    LoyaltyEvil,
+			Option:     "hunter",
+			Requires:   []string{"resistance"},
+			Resistance: true,
+			Sees:       []string{roleEvil},
+			Hunter:     true,
+			FlavorText: "Find the Resistance Chief. Accuse them correctly and the spies win.",
+		},
+		{
+			Name:            "dummyagent",
+			Loyalty:         LoyaltyGood,
+			Option:          "dummyagent",
+			Requires:        []string{"hunter"},
+			Resistance:      true,
+			PlaysChiefCards: true,
+			FlavorText:      "You play chief cards to draw the Spy Hunter away from the Resistance Chief.",
+		},
+	}
+)
+
+// LookupRole returns the registered role with the given name, including the
+// plain "good" and "evil" roles.
+func LookupRole(name string) (Role, bool) {
+	switch name {
+	case roleGood:
+		return goodRole, true
+	case roleEvil:
+		return evilRole, true
+	}
+
+	for _, role := range registeredRoles {
+		if role.Name == name {
+			return role, true
+		}
+	}
+
+	return Role{}, false
+}
+
+// roleOptions returns every option that puts a role in the game, once each,
+// in registration order.
+func roleOptions() []string {
+	var options []string
+	for _, role := range registeredRoles {
+		if role.Option != "" && !contains(options, role.Option) {
+			options = append(options, role.Option)
+		}
+	}
+
+	return options
+}
+
+// isResistanceOption returns whether the option only puts roles from The
+// Resistance in the game.
+func isResistanceOption(option string) bool {
+	for _, role := range registeredRoles {
+		if role.Option == option && !role.Resistance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEvilRole returns whether the given role is on the side of evil.
+func isEvilRole(name string) bool {
+	role, _ := LookupRole(name)
+	return role.Loyalty == LoyaltyEvil
+}
+
+// isPlainRole returns whether the given role is plain good or evil rather than
+// a special character.
+func isPlainRole(name string) bool {
+	return name == roleGood || name == roleEvil
+}
+
+// in returns the role as it plays in a game with the given config, with
+// everything it SeesWith the enabled options added to what it Sees.
+func (r Role) in(ac *AvalonConfig) Role {
+	sees := append([]string{}, r.Sees...)
+	for option, extra := range r.SeesWith {
+		if ac.IsOptionEnabled(option) {
+			sees = append(sees, extra...)
+		}
+	}
+	r.Sees = sees
+
+	return r
+}
+
+// hiddenFrom returns whether a player with this role stays unseen by a player
+// with the viewer role.
+func (r Role) hiddenFrom(viewer Role) bool {
+	for _, hidden := range r.HiddenFrom {
+		if hidden == viewer.Name || hidden == viewer.Loyalty.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// seenBy returns which of the viewer's Sees entries a player with this role
+// shows up under, or "" if the viewer does not see them at all.
+func (r Role) seenBy(viewer Role) string {
+	if r.hiddenFrom(viewer) {
+		return ""
+	}
+
+	for _, sees := range viewer.Sees {
+		if sees == r.Name || sees == r.AppearsAs || sees == r.Loyalty.String() {
+			return sees
+		}
+	}
+
+	return ""
+}
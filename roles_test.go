@@ -0,0 +1,102 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupRole(t *testing.T) {
+	var tests = []struct {
+		name    string
+		found   bool
+		loyalty Loyalty
+	}{
+		{"merlin", true, LoyaltyGood},
+		{"oberon", true, LoyaltyEvil},
+		{"good", true, LoyaltyGood},
+		{"evil", true, LoyaltyEvil},
+		{"justin", false, LoyaltyUnknown},
+	}
+
+	for _, test := range tests {
+		role, ok := LookupRole(test.name)
+		if ok != test.found || role.Loyalty != test.loyalty {
+			t.Errorf("wanted %t and %s for %s, got %t and %s", test.found, test.loyalty, test.name, ok, role.Loyalty)
+		}
+	}
+}
+
+// Every registered role must be usable: a unique name, a side, flavor text and
+// an option that can actually be enabled.
+func TestRegisteredRoles(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, role := range registeredRoles {
+		if seen[role.Name] || isPlainRole(role.Name) {
+			t.Errorf("role name %s is registered twice", role.Name)
+		}
+		seen[role.Name] = true
+
+		if role.Loyalty != LoyaltyGood && role.Loyalty != LoyaltyEvil {
+			t.Errorf("%s has no side", role.Name)
+		}
+		if role.FlavorText == "" {
+			t.Errorf("%s has no flavor text", role.Name)
+		}
+		if role.Option != "" && !OptionExists(role.Option) {
+			t.Errorf("%s is enabled by %s, which does not exist", role.Name, role.Option)
+		}
+	}
+}
+
+func TestRolesInGame(t *testing.T) {
+	var tests = []struct {
+		options []string
+		want    []string
+	}{
+		{nil, []string{"merlin", "assassin"}},
+		{[]string{"morganapercival"}, []string{"merlin", "percival", "assassin", "morgana"}},
+		{[]string{"oberon", "lake"}, []string{"merlin", "assassin", "oberon"}},
+	}
+
+	for _, test := range tests {
+		config := NewAvalonConfig()
+		config.EnableMany(test.options)
+
+		var names []string
+		for _, role := range config.RolesInGame() {
+			names = append(names, role.Name)
+		}
+
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("wanted %v for %v, got %v", test.want, test.options, names)
+		}
+	}
+}
+
+func TestSeenBy(t *testing.T) {
+	var tests = []struct {
+		role   string
+		viewer string
+		want   string
+	}{
+		{"assassin", "merlin", "evil"},
+		{"mordred", "merlin", ""},
+		{"oberon", "merlin", "evil"},
+		{"oberon", "assassin", ""},
+		{"assassin", "oberon", ""},
+		{"merlin", "percival", "merlin"},
+		{"morgana", "percival", "merlin"},
+		{"morgana", "evil", "evil"},
+		{"good", "merlin", ""},
+		{"merlin", "good", ""},
+	}
+
+	for _, test := range tests {
+		role, _ := LookupRole(test.role)
+		viewer, _ := LookupRole(test.viewer)
+
+		if seen := role.seenBy(viewer); seen != test.want {
+			t.Errorf("wanted %s to see %s as %q, got %q", test.viewer, test.role, test.want, seen)
+		}
+	}
+}
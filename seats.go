@@ -0,0 +1,61 @@
+package avalon
+
+// seatOf returns the index of the given player in the seating order or -1 if
+// they do not have a seat.
+func (av *Avalon) seatOf(nick string) int {
+	for i, seated := range av.Seats {
+		if seated == nick {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// neighbour returns the player offset seats clockwise from nick, or "" if nick
+// does not have a seat.
+func (av *Avalon) neighbour(nick string, offset int) string {
+	i := av.seatOf(nick)
+	if i < 0 {
+		return ""
+	}
+
+	n := len(av.Seats)
+	return av.Seats[((i+offset)%n+n)%n]
+}
+
+// LeftOf returns the player seated to the left of nick, who is next clockwise,
+// or "" if nick does not have a seat.
+func (av *Avalon) LeftOf(nick string) string {
+	return av.neighbour(nick, 1)
+}
+
+// RightOf returns the player seated to the right of nick, who is next
+// counter-clockwise, or "" if nick does not have a seat.
+func (av *Avalon) RightOf(nick string) string {
+	return av.neighbour(nick, -1)
+}
+
+// NextLeader returns the player who will lead after the current leader.
+// Leadership passes clockwise after every proposal.
+func (av *Avalon) NextLeader() string {
+	return av.LeftOf(av.CurrentLeader)
+}
+
+// LeaderSequence returns the current leader followed by everyone who will lead
+// if each remaining proposal on this quest is rejected, up to the proposal
+// that would hand the game to evil.
+func (av *Avalon) LeaderSequence() []string {
+	if av.CurrentLeader == "" {
+		return nil
+	}
+
+	var leaders []string
+	leader := av.CurrentLeader
+	for i := av.VoteTrack; i < MaxRejections; i++ {
+		leaders = append(leaders, leader)
+		leader = av.LeftOf(leader)
+	}
+
+	return leaders
+}
@@ -0,0 +1,59 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNeighbours(t *testing.T) {
+	var tests = []struct {
+		nick  string
+		left  string
+		right string
+	}{
+		{"A", "B", "E"},
+		{"C", "D", "B"},
+		{"E", "A", "D"},
+		{"Z", "", ""},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Seats = []string{"A", "B", "C", "D", "E"}
+
+		if left := avalon.LeftOf(test.nick); left != test.left {
+			t.Errorf("wanted %s left of %s, got %s", test.left, test.nick, left)
+		}
+		if right := avalon.RightOf(test.nick); right != test.right {
+			t.Errorf("wanted %s right of %s, got %s", test.right, test.nick, right)
+		}
+	}
+}
+
+func TestLeaderSequence(t *testing.T) {
+	var tests = []struct {
+		leader    string
+		voteTrack int
+		next      string
+		want      []string
+	}{
+		{"", 0, "", nil},
+		{"A", 0, "B", []string{"A", "B", "C", "D", "E"}},
+		{"D", 2, "E", []string{"D", "E", "A"}},
+		{"E", 4, "A", []string{"E"}},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Seats = []string{"A", "B", "C", "D", "E"}
+		avalon.CurrentLeader = test.leader
+		avalon.VoteTrack = test.voteTrack
+
+		if next := avalon.NextLeader(); next != test.next {
+			t.Errorf("wanted %s to lead after %s, got %s", test.next, test.leader, next)
+		}
+		if leaders := avalon.LeaderSequence(); !reflect.DeepEqual(leaders, test.want) {
+			t.Errorf("wanted %v from %s with vote track %d, got %v", test.want, test.leader, test.voteTrack, leaders)
+		}
+	}
+}
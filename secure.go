@@ -0,0 +1,41 @@
+package avalon
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// SecureRandom is a Random backed by crypto/rand, for games where players
+// must not be able to predict or reconstruct the assignment. Intn draws
+// without modulo bias and Perm is an unbiased Fisher-Yates shuffle.
+type SecureRandom struct{}
+
+// Intn returns a uniformly random int in [0, n). It panics if n <= 0 or if
+// the system's secure random source fails.
+func (SecureRandom) Intn(n int) int {
+	if n <= 0 {
+		panic("avalon: invalid argument to Intn")
+	}
+
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic("avalon: secure random source failed: " + err.Error())
+	}
+
+	return int(v.Int64())
+}
+
+// Perm returns a uniformly random permutation of the ints [0, n).
+func (sr SecureRandom) Perm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	for i := n - 1; i > 0; i-- {
+		j := sr.Intn(i + 1)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+
+	return perm
+}
@@ -0,0 +1,115 @@
+package avalon
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// chiSquared returns the chi-squared statistic for observed counts against the
+// same expected count in every bucket.
+func chiSquared(observed map[string]int, buckets []string, expected float64) float64 {
+	var stat float64
+	for _, bucket := range buckets {
+		diff := float64(observed[bucket]) - expected
+		stat += diff * diff / expected
+	}
+
+	return stat
+}
+
+// Chi-squared critical value for 6 degrees of freedom at p = 0.0001. Seven
+// players gives six degrees of freedom, and the tiny p-value keeps these
+// statistical tests from flaking.
+const chiSquaredCritical6 = 27.86
+
+// checkUniformity assigns roles many times and checks that every player is
+// equally likely to be evil and to get each special character.
+func checkUniformity(t *testing.T, random Random) {
+	const runs = 3500
+	players := []string{"A", "B", "C", "D", "E", "F", "G"}
+	options := []string{"mordred", "morganapercival"}
+
+	evils := make(map[string]int)
+	leaders := make(map[string]int)
+	specials := make(map[string]map[string]int)
+
+	for i := 0; i < runs; i++ {
+		avalon := NewAvalon()
+		avalon.Players = players
+		avalon.EnableMany(options)
+
+		ass := NewAssigner(avalon, random)
+		if err := ass.Assign(); err != nil {
+			t.Fatalf("unexpected error assigning: %v", err)
+		}
+
+		for _, nick := range avalon.Evils {
+			evils[nick]++
+		}
+		leaders[avalon.CurrentLeader]++
+		for special, nick := range avalon.Specials {
+			if specials[special] == nil {
+				specials[special] = make(map[string]int)
+			}
+			specials[special][nick]++
+		}
+	}
+
+	numPlayers := float64(len(players))
+	numEvils := float64(numEvils(len(players)))
+
+	if stat := chiSquared(evils, players, runs*numEvils/numPlayers); stat > chiSquaredCritical6 {
+		t.Errorf("evils not uniform (chi-squared %.2f): %v", stat, evils)
+	}
+	if stat := chiSquared(leaders, players, runs/numPlayers); stat > chiSquaredCritical6 {
+		t.Errorf("first leaders not uniform (chi-squared %.2f): %v", stat, leaders)
+	}
+
+	for _, special := range []string{"merlin", "percival", "assassin", "mordred", "morgana"} {
+		counts := specials[special]
+		if stat := chiSquared(counts, players, runs/numPlayers); stat > chiSquaredCritical6 {
+			t.Errorf("%s not uniform (chi-squared %.2f): %v", special, stat, counts)
+		}
+	}
+}
+
+// SecureRandom cannot be seeded, so its run may flake, however rarely, and is
+// skipped in short mode.
+func TestSecureRandomUniformity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping unseeded uniformity test in short mode")
+	}
+
+	checkUniformity(t, SecureRandom{})
+}
+
+func TestSeededRandomUniformity(t *testing.T) {
+	checkUniformity(t, rand.New(rand.NewSource(1)))
+}
+
+func TestSecureRandomPerm(t *testing.T) {
+	var sr SecureRandom
+
+	for n := 0; n < 10; n++ {
+		perm := sr.Perm(n)
+		seen := make(map[int]bool)
+		for _, v := range perm {
+			if v < 0 || v >= n || seen[v] {
+				t.Fatalf("invalid permutation of %d: %v", n, perm)
+			}
+			seen[v] = true
+		}
+		if len(perm) != n {
+			t.Errorf("expected permutation of length %d, got %v", n, perm)
+		}
+	}
+}
+
+func TestStartSecure(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"secure"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	runRequirementTests(t, av)
+}
@@ -0,0 +1,211 @@
+package avalon
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SnapshotVersion is the version of the JSON format written by Snapshot.
+// Restore refuses snapshots written with any other version.
+const SnapshotVersion = 1
+
+var (
+	// ErrUnsupportedSnapshot indicates that a snapshot was written with a
+	// format version this package cannot read.
+	ErrUnsupportedSnapshot = errors.New("avalon: unsupported snapshot version")
+)
+
+// snapshot is the versioned JSON representation of an Avalon game, including
+// the state that is not exported on Avalon itself.
+type snapshot struct {
+	Version int             `json:"version"`
+	Options map[string]bool `json:"options"`
+
+	Players  []string          `json:"players"`
+	Seats    []string          `json:"seats"`
+	Goods    []string          `json:"goods"`
+	Evils    []string          `json:"evils"`
+	Specials map[string]string `json:"specials"`
+
+	FirstLeader      string  `json:"first_leader,omitempty"`
+	FirstLeaderShown Loyalty `json:"first_leader_shown,omitempty"`
+
+	LancelotDeck  []bool `json:"lancelot_deck,omitempty"`
+	LancelotFlips int    `json:"lancelot_flips,omitempty"`
+
+	PlotDeck         []string            `json:"plot_deck,omitempty"`
+	PlotDrawn        int                 `json:"plot_drawn,omitempty"`
+	CurrentPlotCards []string            `json:"current_plot_cards,omitempty"`
+	PlotHands        map[string][]string `json:"plot_hands,omitempty"`
+
+	Commitment string `json:"commitment,omitempty"`
+	Salt       string `json:"salt,omitempty"`
+
+	Phase                Phase           `json:"phase"`
+	CurrentQuest         int             `json:"current_quest"`
+	CurrentTarget        int             `json:"current_target,omitempty"`
+	CurrentLake          string          `json:"current_lake,omitempty"`
+	CurrentLeader        string          `json:"current_leader,omitempty"`
+	CurrentProposedParty []string        `json:"current_proposed_party,omitempty"`
+	CurrentExcalibur     string          `json:"current_excalibur,omitempty"`
+	CurrentVotes         map[string]bool `json:"current_votes,omitempty"`
+	QuestCardsPlayed     map[string]bool `json:"quest_cards_played,omitempty"`
+	QuestFailsPlayed     int             `json:"quest_fails_played,omitempty"`
+	VoteTrack            int             `json:"vote_track"`
+
+	QuestHistory        []QuestRecord `json:"quest_history"`
+	LakeUses            []LakeUse     `json:"lake_uses,omitempty"`
+	PlotUses            []PlotUse     `json:"plot_uses,omitempty"`
+	AssassinationTarget string        `json:"assassination_target,omitempty"`
+	ServantTurned       bool          `json:"servant_turned,omitempty"`
+	PendingAnswer       string        `json:"pending_answer,omitempty"`
+
+	CurrentInvestigator string          `json:"current_investigator,omitempty"`
+	Investigations      []Investigation `json:"investigations,omitempty"`
+	Accuser             string          `json:"accuser,omitempty"`
+	AccusationTarget    string          `json:"accusation_target,omitempty"`
+
+	Events []Event `json:"events,omitempty"`
+}
+
+// MarshalJSON encodes the whole game, including the config and unexported
+// state, in the current snapshot format.
+func (av *Avalon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(snapshot{
+		Version: SnapshotVersion,
+		Options: av.OptionsEnabled,
+
+		Players:  av.Players,
+		Seats:    av.Seats,
+		Goods:    av.Goods,
+		Evils:    av.Evils,
+		Specials: av.Specials,
+
+		FirstLeader:      av.FirstLeader,
+		FirstLeaderShown: av.FirstLeaderShown,
+
+		LancelotDeck:  av.LancelotDeck,
+		LancelotFlips: av.LancelotFlips,
+
+		PlotDeck:         av.PlotDeck,
+		PlotDrawn:        av.PlotDrawn,
+		CurrentPlotCards: av.CurrentPlotCards,
+		PlotHands:        av.PlotHands,
+
+		Commitment: av.Commitment,
+		Salt:       av.salt,
+
+		Phase:                av.Phase,
+		CurrentQuest:         av.CurrentQuest,
+		CurrentTarget:        av.CurrentTarget,
+		CurrentLake:          av.CurrentLake,
+		CurrentLeader:        av.CurrentLeader,
+		CurrentProposedParty: av.CurrentProposedParty,
+		CurrentExcalibur:     av.CurrentExcalibur,
+		CurrentVotes:         av.CurrentVotes,
+		QuestCardsPlayed:     av.questCardsPlayed,
+		QuestFailsPlayed:     av.questFailsPlayed,
+		VoteTrack:            av.VoteTrack,
+
+		QuestHistory:        av.QuestHistory,
+		LakeUses:            av.LakeUses,
+		PlotUses:            av.PlotUses,
+		AssassinationTarget: av.AssassinationTarget,
+		ServantTurned:       av.ServantTurned,
+		PendingAnswer:       av.PendingAnswer,
+
+		CurrentInvestigator: av.CurrentInvestigator,
+		Investigations:      av.Investigations,
+		Accuser:             av.Accuser,
+		AccusationTarget:    av.AccusationTarget,
+
+		Events: av.events,
+	})
+}
+
+// UnmarshalJSON replaces the game with one decoded from the snapshot format.
+func (av *Avalon) UnmarshalJSON(data []byte) error {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+
+	if snap.Version != SnapshotVersion {
+		return ErrUnsupportedSnapshot
+	}
+
+	*av = Avalon{
+		AvalonConfig: NewAvalonConfig(),
+		Specials:     make(map[string]string),
+
+		Players: snap.Players,
+		Seats:   snap.Seats,
+		Goods:   snap.Goods,
+		Evils:   snap.Evils,
+
+		FirstLeader:      snap.FirstLeader,
+		FirstLeaderShown: snap.FirstLeaderShown,
+
+		LancelotDeck:  snap.LancelotDeck,
+		LancelotFlips: snap.LancelotFlips,
+
+		PlotDeck:         snap.PlotDeck,
+		PlotDrawn:        snap.PlotDrawn,
+		CurrentPlotCards: snap.CurrentPlotCards,
+		PlotHands:        snap.PlotHands,
+
+		Commitment: snap.Commitment,
+		salt:       snap.Salt,
+
+		Phase:                snap.Phase,
+		CurrentQuest:         snap.CurrentQuest,
+		CurrentTarget:        snap.CurrentTarget,
+		CurrentLake:          snap.CurrentLake,
+		CurrentLeader:        snap.CurrentLeader,
+		CurrentProposedParty: snap.CurrentProposedParty,
+		CurrentExcalibur:     snap.CurrentExcalibur,
+		CurrentVotes:         snap.CurrentVotes,
+		questCardsPlayed:     snap.QuestCardsPlayed,
+		questFailsPlayed:     snap.QuestFailsPlayed,
+		VoteTrack:            snap.VoteTrack,
+
+		QuestHistory:        snap.QuestHistory,
+		LakeUses:            snap.LakeUses,
+		PlotUses:            snap.PlotUses,
+		AssassinationTarget: snap.AssassinationTarget,
+		ServantTurned:       snap.ServantTurned,
+		PendingAnswer:       snap.PendingAnswer,
+
+		CurrentInvestigator: snap.CurrentInvestigator,
+		Investigations:      snap.Investigations,
+		Accuser:             snap.Accuser,
+		AccusationTarget:    snap.AccusationTarget,
+
+		events: snap.Events,
+	}
+
+	for option, enabled := range snap.Options {
+		av.OptionsEnabled[option] = enabled
+	}
+	for special, nick := range snap.Specials {
+		av.Specials[special] = nick
+	}
+
+	return nil
+}
+
+// Snapshot serializes the game so that it can be stored and later picked up
+// again with Restore.
+func (av *Avalon) Snapshot() ([]byte, error) {
+	return json.Marshal(av)
+}
+
+// Restore rebuilds a game from data written by Snapshot.
+func Restore(data []byte) (*Avalon, error) {
+	av := NewAvalon()
+	if err := json.Unmarshal(data, av); err != nil {
+		return nil, err
+	}
+
+	return av, nil
+}
@@ -0,0 +1,104 @@
+package avalon
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"lake", "mordred"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	playQuest(t, av, true)
+	playQuest(t, av, false)
+	holder := av.CurrentLake
+	target := av.LeftOf(holder)
+	if _, err := av.UseLake(holder, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.ClaimLake(holder, LoyaltyEvil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Leave the game partway through a quest
+	party := validParty(av)
+	if err := av.ProposeParty(av.CurrentLeader, party); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, true)
+	if err := av.PlayQuestCard(party[0], true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := av.Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored, err := Restore(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(av, restored) {
+		t.Errorf("restored game differs from original\noriginal: %+v\nrestored: %+v", av, restored)
+	}
+
+	// The restored game carries on where the original left off
+	for _, nick := range party[1:] {
+		if err := restored.PlayQuestCard(nick, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := len(restored.QuestSuccesses()); n != 3 {
+		t.Errorf("expected 3 quests resolved after restoring, got %d", n)
+	}
+}
+
+func TestSnapshotFormat(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(av)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{`"version":1`, `"phase":"assigned"`, `"specials":{`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	var tests = []struct {
+		data    string
+		wantErr error
+	}{
+		{`{"version":2}`, ErrUnsupportedSnapshot},
+		{`{"players":["A"]}`, ErrUnsupportedSnapshot},
+		{`{"version":1,"phase":"napping"}`, nil},
+		{`not json`, nil},
+	}
+
+	for _, test := range tests {
+		_, err := Restore([]byte(test.data))
+		if err == nil {
+			t.Errorf("expected error restoring %s, got none", test.data)
+			continue
+		}
+		if test.wantErr != nil && err != test.wantErr {
+			t.Errorf("wanted %v restoring %s, got %v", test.wantErr, test.data, err)
+		}
+	}
+}
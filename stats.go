@@ -0,0 +1,98 @@
+package avalon
+
+import (
+	"math"
+)
+
+// RoleStats is how often each player got each role over many simulated
+// assignments, used to measure how far a Strategy strays from uniform.
+type RoleStats struct {
+	Runs int
+
+	// Fraction of runs each player was evil, and fraction of runs each
+	// special character went to each player.
+	EvilRate map[string]float64
+	RoleRate map[string]map[string]float64
+
+	// What the rates would be if every player were equally likely to get
+	// every role.
+	UniformEvilRate float64
+	UniformRoleRate map[string]float64
+}
+
+// SimulateRoles assigns roles runs times for the given players and options
+// using strategy, without touching the game itself.
+func SimulateRoles(av *Avalon, strategy Strategy, random Random, runs int) RoleStats {
+	if random == nil {
+		random = globalRandom{}
+	}
+
+	stats := RoleStats{
+		Runs:            runs,
+		EvilRate:        make(map[string]float64),
+		RoleRate:        make(map[string]map[string]float64),
+		UniformEvilRate: float64(av.NumEvils()) / float64(av.NumPlayers()),
+		UniformRoleRate: make(map[string]float64),
+	}
+
+	for _, role := range roleCards(av) {
+		if !isPlainRole(role) {
+			stats.UniformRoleRate[role] = 1 / float64(av.NumPlayers())
+		}
+	}
+
+	for i := 0; i < runs; i++ {
+		sim := NewAvalon()
+		sim.Players = av.Players
+		for option, enabled := range av.OptionsEnabled {
+			sim.OptionsEnabled[option] = enabled
+		}
+
+		if err := strategy.AssignRoles(sim, random); err != nil {
+			continue
+		}
+
+		for _, nick := range sim.Evils {
+			stats.EvilRate[nick]++
+		}
+		for special, nick := range sim.Specials {
+			if stats.RoleRate[special] == nil {
+				stats.RoleRate[special] = make(map[string]float64)
+			}
+			stats.RoleRate[special][nick]++
+		}
+	}
+
+	for nick := range stats.EvilRate {
+		stats.EvilRate[nick] /= float64(runs)
+	}
+	for _, rates := range stats.RoleRate {
+		for nick := range rates {
+			rates[nick] /= float64(runs)
+		}
+	}
+
+	return stats
+}
+
+// EvilBias returns the largest difference between any player's evil rate and
+// the uniform rate.
+func (rs RoleStats) EvilBias(players []string) float64 {
+	var bias float64
+	for _, nick := range players {
+		bias = math.Max(bias, math.Abs(rs.EvilRate[nick]-rs.UniformEvilRate))
+	}
+
+	return bias
+}
+
+// RoleBias returns the largest difference between any player's rate for the
+// special character and the uniform rate.
+func (rs RoleStats) RoleBias(special string, players []string) float64 {
+	var bias float64
+	for _, nick := range players {
+		bias = math.Max(bias, math.Abs(rs.RoleRate[special][nick]-rs.UniformRoleRate[special]))
+	}
+
+	return bias
+}
@@ -0,0 +1,179 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrRoleUnavailable indicates that a strategy tried to give a player a
+	// role that is not in this game or has already been given out.
+	ErrRoleUnavailable = errors.New("avalon: role is not available in this game")
+)
+
+// Strategy decides which player gets which role. Every strategy populates the
+// game's Goods, Evils and Specials with the same number of each as the config
+// requires; they differ only in how players are matched to roles.
+type Strategy interface {
+	AssignRoles(av *Avalon, random Random) error
+}
+
+// roleCards returns one role for every player in the game: each enabled
+// special character, padded out with plain goods and evils.
+func roleCards(av *Avalon) []string {
+	goods := av.specialsInGame(LoyaltyGood)
+	evils := av.specialsInGame(LoyaltyEvil)
+
+	for len(goods) < av.NumGoods() {
+		goods = append(goods, roleGood)
+	}
+	for len(evils) < av.NumEvils() {
+		evils = append(evils, roleEvil)
+	}
+
+	return append(goods, evils...)
+}
+
+// dealRoles populates the game's Goods, Evils and Specials from a role for
+// every player.
+func dealRoles(av *Avalon, roles map[string]string) {
+	for _, nick := range av.Players {
+		role := roles[nick]
+		if isEvilRole(role) {
+			av.Evils = append(av.Evils, nick)
+		} else {
+			av.Goods = append(av.Goods, nick)
+		}
+
+		if !isPlainRole(role) {
+			av.Specials[role] = nick
+		}
+	}
+}
+
+// takeRole removes a single copy of role from cards, reporting whether it was
+// there to take.
+func takeRole(cards []string, role string) ([]string, bool) {
+	for i, card := range cards {
+		if card == role {
+			return deleteAt(cards, i), true
+		}
+	}
+
+	return cards, false
+}
+
+// RandomStrategy picks goods and evils uniformly at random, then picks the
+// special characters uniformly from each side.
+type RandomStrategy struct{}
+
+// AssignRoles implements Strategy.
+func (RandomStrategy) AssignRoles(av *Avalon, random Random) error {
+	randomOrder := random.Perm(av.NumPlayers())
+
+	for i, n := range randomOrder {
+		nick := av.Players[n]
+		if i < av.NumGoods() {
+			av.Goods = append(av.Goods, nick)
+		} else {
+			av.Evils = append(av.Evils, nick)
+		}
+	}
+
+	// ==========
+	// == Good ==
+	// ==========
+	randomOrder = random.Perm(av.NumGoods())
+	for i, special := range av.specialsInGame(LoyaltyGood) {
+		av.Specials[special] = av.Goods[randomOrder[i]]
+	}
+
+	// ==========
+	// == Evil ==
+	// ==========
+	randomOrder = random.Perm(av.NumEvils())
+	for i, special := range av.specialsInGame(LoyaltyEvil) {
+		av.Specials[special] = av.Evils[randomOrder[i]]
+	}
+
+	return nil
+}
+
+// ForcedStrategy gives chosen players chosen roles, for tutorials and
+// demonstrations, and deals the rest of the roles randomly. Roles maps a
+// player to a special character or to "good" or "evil" for a plain role.
+type ForcedStrategy struct {
+	Roles map[string]string
+}
+
+// AssignRoles implements Strategy. Errors if a forced role is not part of this
+// game or is forced on more players than the game has room for.
+func (fs ForcedStrategy) AssignRoles(av *Avalon, random Random) error {
+	cards := roleCards(av)
+	roles := make(map[string]string, av.NumPlayers())
+
+	for nick := range fs.Roles {
+		if !av.PlayerExists(nick) {
+			return ErrPlayerNotFound
+		}
+	}
+
+	var unforced []string
+	for _, nick := range av.Players {
+		role, ok := fs.Roles[nick]
+		if !ok {
+			unforced = append(unforced, nick)
+			continue
+		}
+
+		var available bool
+		if cards, available = takeRole(cards, role); !available {
+			return ErrRoleUnavailable
+		}
+		roles[nick] = role
+	}
+
+	for i, n := range random.Perm(len(cards)) {
+		roles[unforced[i]] = cards[n]
+	}
+
+	dealRoles(av, roles)
+	return nil
+}
+
+// DraftStrategy lets players choose their roles. Players pick one at a time in
+// a random order, each taking the first of their Preferences that is still
+// available. Once the draft is over, the roles nobody picked are dealt
+// randomly to the players who did not get any of their preferences.
+type DraftStrategy struct {
+	Preferences map[string][]string
+}
+
+// AssignRoles implements Strategy.
+func (ds DraftStrategy) AssignRoles(av *Avalon, random Random) error {
+	cards := roleCards(av)
+	roles := make(map[string]string, av.NumPlayers())
+
+	var undrafted []string
+	for _, n := range random.Perm(av.NumPlayers()) {
+		nick := av.Players[n]
+
+		var picked bool
+		for _, role := range ds.Preferences[nick] {
+			if cards, picked = takeRole(cards, role); picked {
+				roles[nick] = role
+				break
+			}
+		}
+
+		if !picked {
+			undrafted = append(undrafted, nick)
+		}
+	}
+
+	for i, n := range random.Perm(len(cards)) {
+		roles[undrafted[i]] = cards[n]
+	}
+
+	dealRoles(av, roles)
+	return nil
+}
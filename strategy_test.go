@@ -0,0 +1,122 @@
+package avalon
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRoleCards(t *testing.T) {
+	var tests = []struct {
+		numPlayers int
+		options    []string
+		want       []string
+	}{
+		{
+			5, nil,
+			[]string{"assassin", "evil", "good", "good", "merlin"},
+		},
+		{
+			7, []string{"mordred", "morganapercival"},
+			[]string{"assassin", "good", "good", "merlin", "mordred", "morgana", "percival"},
+		},
+		{
+			10, []string{"mordred", "morganapercival", "oberon"},
+			[]string{"assassin", "good", "good", "good", "good", "merlin", "mordred", "morgana", "oberon", "percival"},
+		},
+	}
+
+	for _, test := range tests {
+		avalon := NewAvalon()
+		avalon.Players = make([]string, test.numPlayers)
+		avalon.EnableMany(test.options)
+
+		cards := roleCards(avalon)
+		sort.Strings(cards)
+		if !setsEqual(cards, test.want) {
+			t.Errorf("wanted %v for %d players with %v, got %v", test.want, test.numPlayers, test.options, cards)
+		}
+	}
+}
+
+func TestForcedStrategy(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		avalon := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"morganapercival"})
+		avalon.SetStrategy(ForcedStrategy{Roles: map[string]string{
+			"A": "merlin",
+			"B": "morgana",
+			"C": "evil",
+			"D": "good",
+		}})
+
+		if err := avalon.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		runRequirementTests(t, avalon)
+
+		if avalon.Specials["merlin"] != "A" || avalon.Specials["morgana"] != "B" {
+			t.Errorf("forced specials not assigned: %v", avalon.Specials)
+		}
+		if !avalon.IsEvil("C") || avalon.RoleOf("C") != "" {
+			t.Errorf("expected C to be a plain evil, got %s %q", avalon.LoyaltyOf("C"), avalon.RoleOf("C"))
+		}
+		if !avalon.IsGood("D") || avalon.RoleOf("D") != "" {
+			t.Errorf("expected D to be a plain good, got %s %q", avalon.LoyaltyOf("D"), avalon.RoleOf("D"))
+		}
+	}
+}
+
+func TestForcedStrategyErrors(t *testing.T) {
+	var tests = []struct {
+		roles map[string]string
+		want  error
+	}{
+		{map[string]string{"A": "percival"}, ErrRoleUnavailable},
+		{map[string]string{"A": "merlin", "B": "merlin"}, ErrRoleUnavailable},
+		{map[string]string{"A": "evil", "B": "evil", "C": "evil"}, ErrRoleUnavailable},
+		{map[string]string{"Z": "merlin"}, ErrPlayerNotFound},
+	}
+
+	for _, test := range tests {
+		avalon := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, nil)
+		avalon.SetStrategy(ForcedStrategy{Roles: test.roles})
+
+		err := avalon.Start()
+		if err != test.want {
+			t.Errorf("wanted %v forcing %v, got %v", test.want, test.roles, err)
+		}
+
+		if avalon.Phase != PhaseLobby || len(avalon.Goods) != 0 || len(avalon.Evils) != 0 || len(avalon.Specials) != 0 {
+			t.Errorf("failed start left roles behind: %+v", avalon.assignment())
+		}
+	}
+}
+
+func TestDraftStrategy(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		avalon := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"mordred"})
+		avalon.SetStrategy(DraftStrategy{Preferences: map[string][]string{
+			"A": {"merlin"},
+			"B": {"merlin", "assassin"},
+			"C": {"mordred", "evil"},
+			"D": {"assassin"},
+		}})
+
+		if err := avalon.Start(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		runRequirementTests(t, avalon)
+
+		if avalon.Specials["mordred"] != "C" {
+			t.Errorf("expected C to draft mordred, got %v", avalon.Specials)
+		}
+
+		// A and B both want Merlin, so whoever picks first gets it
+		merlin := avalon.Specials["merlin"]
+		if merlin != "A" && merlin != "B" {
+			t.Errorf("expected A or B to draft merlin, got %s", merlin)
+		}
+		if merlin == "A" && avalon.Specials["assassin"] != "B" && avalon.Specials["assassin"] != "D" {
+			t.Errorf("expected B or D to draft assassin, got %v", avalon.Specials)
+		}
+	}
+}
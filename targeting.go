@@ -0,0 +1,117 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrTargetRequired indicates that a party was proposed without choosing
+	// its quest while the targeting option is enabled.
+	ErrTargetRequired = errors.New("avalon: the leader must choose which quest the party attempts")
+
+	// ErrNoSuchQuest indicates that a quest outside the five on the board was
+	// targeted.
+	ErrNoSuchQuest = errors.New("avalon: there is no such quest")
+
+	// ErrQuestAttempted indicates that a quest which has already been
+	// resolved was targeted.
+	ErrQuestAttempted = errors.New("avalon: that quest has already been attempted")
+
+	// ErrLastQuestLocked indicates that Quest 5 was targeted before two other
+	// quests succeeded.
+	ErrLastQuestLocked = errors.New("avalon: quest 5 can only be attempted after two successful quests")
+)
+
+// With the targeting option, the leader chooses which remaining quest their
+// party attempts as part of their proposal instead of playing the quests in
+// order. CurrentQuest still counts how many quests have been attempted, while
+// the quest being attempted is CurrentTarget. Until a party is proposed,
+// CurrentTarget is the first quest that may be attempted. Quest 5 is locked
+// until two quests have succeeded.
+
+// noTarget is the quest of a proposal that did not choose one.
+const noTarget = -1
+
+// targetQuest returns which quest (zero-indexed) the current party attempts.
+func (av *Avalon) targetQuest() int {
+	if !av.IsOptionEnabled("targeting") {
+		return av.CurrentQuest
+	}
+
+	return av.CurrentTarget
+}
+
+// lastQuest returns which quest (zero-indexed) was most recently attempted.
+func (av *Avalon) lastQuest() int {
+	return av.QuestHistory[av.CurrentQuest-1].Quest
+}
+
+// RemainingQuests returns every quest (zero-indexed) that has not been
+// attempted yet, in order.
+func (av *Avalon) RemainingQuests() []int {
+	attempted := make(map[int]bool)
+	for _, record := range av.QuestHistory {
+		if record.Resolved {
+			attempted[record.Quest] = true
+		}
+	}
+
+	var remaining []int
+	for quest := 0; quest < av.numQuests(); quest++ {
+		if !attempted[quest] {
+			remaining = append(remaining, quest)
+		}
+	}
+
+	return remaining
+}
+
+// numQuests returns the number of quests on the board.
+func (av *Avalon) numQuests() int {
+	return len(numPlayersToQuestSizes[av.NumPlayers()])
+}
+
+// validateTarget returns why the given quest cannot be attempted next, if it
+// cannot.
+func (av *Avalon) validateTarget(quest int) error {
+	if quest < 0 || quest >= av.numQuests() {
+		return ErrNoSuchQuest
+	}
+
+	for _, record := range av.QuestHistory {
+		if record.Resolved && record.Quest == quest {
+			return ErrQuestAttempted
+		}
+	}
+
+	if quest == av.numQuests()-1 && av.NumSuccesses() < 2 {
+		return ErrLastQuestLocked
+	}
+
+	return nil
+}
+
+// defaultTarget returns the first quest that may be attempted next.
+func (av *Avalon) defaultTarget() int {
+	for _, quest := range av.RemainingQuests() {
+		if av.validateTarget(quest) == nil {
+			return quest
+		}
+	}
+
+	return av.CurrentQuest
+}
+
+// proposedQuest returns which quest a proposal for the given quest attempts.
+// Without the targeting option, that is always the current quest.
+func (av *Avalon) proposedQuest(quest int) (int, error) {
+	if !av.IsOptionEnabled("targeting") {
+		return av.CurrentQuest, nil
+	}
+
+	if quest == noTarget {
+		return 0, ErrTargetRequired
+	}
+
+	return quest, av.validateTarget(quest)
+}
@@ -0,0 +1,153 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+// playTargetedQuest sends a party of the first players on the given quest and
+// has it succeed.
+func playTargetedQuest(t *testing.T, av *Avalon, quest int) {
+	party := av.Players[:av.QuestSize(quest)]
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: party, Quest: quest}); err != nil {
+		t.Fatalf("unexpected error proposing: %v", err)
+	}
+	voteAll(t, av, true)
+
+	for _, nick := range party {
+		if err := av.PlayQuestCard(nick, true); err != nil {
+			t.Fatalf("unexpected error questing: %v", err)
+		}
+	}
+}
+
+func TestProposeTarget(t *testing.T) {
+	// Without targeting, the quest is ignored and quests go in order
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, nil)
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: validParty(av), Quest: 2}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if proposal := av.currentProposal(); proposal.Quest != 0 {
+		t.Errorf("expected quest 0 without targeting, got %d", proposal.Quest)
+	}
+
+	av = newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"targeting"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.CurrentTarget != 0 {
+		t.Errorf("expected quest 0 targeted by default, got %d", av.CurrentTarget)
+	}
+
+	// Quest 2 needs 3 players with seven players, quest 0 only 2
+	party := av.Players[:3]
+	var tests = []struct {
+		leader string
+		quest  int
+		party  []string
+		want   error
+	}{
+		{av.LeftOf(av.CurrentLeader), 2, party, ErrNotLeader},
+		{av.CurrentLeader, 5, party, ErrNoSuchQuest},
+		{av.CurrentLeader, -2, party, ErrNoSuchQuest},
+		{av.CurrentLeader, 4, party, ErrLastQuestLocked},
+		{av.CurrentLeader, 0, party, ErrWrongPartySize},
+	}
+
+	for _, test := range tests {
+		if err := av.Propose(test.leader, PartyProposal{Party: test.party, Quest: test.quest}); err != test.want {
+			t.Errorf("proposing for quest %d, wanted %v, got %v", test.quest, test.want, err)
+		}
+	}
+	if err := av.ProposeParty(av.CurrentLeader, av.Players[:2]); err != ErrTargetRequired {
+		t.Errorf("wanted %v, got %v", ErrTargetRequired, err)
+	}
+
+	playTargetedQuest(t, av, 2)
+
+	record := av.QuestHistory[0]
+	if record.Quest != 2 || record.Proposals[0].Quest != 2 || !record.Success {
+		t.Errorf("expected quest 2 to succeed, got %+v", record)
+	}
+	if remaining := av.RemainingQuests(); !reflect.DeepEqual(remaining, []int{0, 1, 3, 4}) {
+		t.Errorf("expected quests 0, 1, 3 and 4 remaining, got %v", remaining)
+	}
+	if av.CurrentTarget != 0 {
+		t.Errorf("expected quest 0 targeted by default, got %d", av.CurrentTarget)
+	}
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: party, Quest: 2}); err != ErrQuestAttempted {
+		t.Errorf("wanted %v, got %v", ErrQuestAttempted, err)
+	}
+
+	// Quest 3 needs two fails with seven players
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: av.Players[:4], Quest: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if av.CurrentQuestSize() != 4 || av.FailsRequired(av.CurrentTarget) != 2 {
+		t.Errorf("expected quest 3 to need 4 players and 2 fails, got %d and %d",
+			av.CurrentQuestSize(), av.FailsRequired(av.CurrentTarget))
+	}
+	voteAll(t, av, false)
+	if av.CurrentTarget != 0 {
+		t.Errorf("expected quest 0 targeted again after a rejection, got %d", av.CurrentTarget)
+	}
+
+	playTargetedQuest(t, av, 3)
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: av.Players[:4], Quest: 4}); err != nil {
+		t.Errorf("unexpected error after two successes: %v", err)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
+
+func TestTargetedHistory(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E", "F", "G"}, []string{"targeting", "lake"})
+	if err := av.Start(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	playTargetedQuest(t, av, 3)
+	playTargetedQuest(t, av, 0)
+	if av.Phase != PhaseLadyOfTheLake {
+		t.Fatalf("expected %s after two quests, got %s", PhaseLadyOfTheLake, av.Phase)
+	}
+
+	target := av.LeftOf(av.CurrentLake)
+	if _, err := av.UseLake(av.CurrentLake, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if use := av.LakeUses[0]; use.Quest != 0 {
+		t.Errorf("expected the lady of the lake used after quest 0, got quest %d", use.Quest)
+	}
+
+	if err := av.Propose(av.CurrentLeader, PartyProposal{Party: av.Players[:3], Quest: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	voteAll(t, av, false)
+
+	var quests []int
+	for _, record := range av.QuestHistory {
+		quests = append(quests, record.Quest)
+	}
+	if !reflect.DeepEqual(quests, []int{3, 0, 1}) {
+		t.Errorf("expected quests 3, 0 and 1 recorded, got %v", quests)
+	}
+}
@@ -0,0 +1,83 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotInvestigated indicates that someone other than the player being
+	// investigated tried to choose what the investigation shows.
+	ErrNotInvestigated = errors.New("avalon: player is not being investigated")
+
+	// ErrInvalidAnswer indicates that an investigated player tried to show a
+	// loyalty other than good or evil.
+	ErrInvalidAnswer = errors.New("avalon: an investigation must show good or evil")
+
+	// ErrAnswerPending indicates that the game was moved on before the player
+	// being investigated chose what to show.
+	ErrAnswerPending = errors.New("avalon: waiting for the investigated player to answer")
+)
+
+// Roles that MayLie, like the Trickster, choose which loyalty to show each time
+// they are investigated. When the Lady of the Lake lands on one, or the Cleric
+// investigates one as the first leader, the game waits in PendingAnswer until
+// they answer with AnswerInvestigation.
+
+// mayLie returns whether the given player chooses what loyalty they show when
+// investigated.
+func (av *Avalon) mayLie(nick string) bool {
+	role, _ := LookupRole(av.RoleName(nick))
+	return role.MayLie
+}
+
+// clericSuspect returns the first leader if the Cleric investigates them and
+// they must choose what to show, or "" if nobody has to answer.
+func (av *Avalon) clericSuspect() string {
+	cleric := av.Specials["cleric"]
+	if cleric == "" || cleric == av.FirstLeader || !av.mayLie(av.FirstLeader) {
+		return ""
+	}
+
+	return av.FirstLeader
+}
+
+// shownToCleric returns the loyalty the first leader shows the Cleric, or
+// LoyaltyUnknown while they are still choosing.
+func (av *Avalon) shownToCleric() Loyalty {
+	if av.mayLie(av.FirstLeader) {
+		return av.FirstLeaderShown
+	}
+
+	return av.investigate(av.FirstLeader)
+}
+
+// AnswerInvestigation has the player being investigated choose which loyalty
+// the Lady of the Lake or the Cleric is shown. The answer should only be told
+// to the investigator. Once it is given, the game goes on.
+func (av *Avalon) AnswerInvestigation(nick string, shown Loyalty) error {
+	if err := av.expectPhase("answer an investigation", PhaseAssigned, PhaseLadyOfTheLake); err != nil {
+		return err
+	}
+
+	if nick == "" || nick != av.PendingAnswer {
+		return ErrNotInvestigated
+	}
+
+	if shown != LoyaltyGood && shown != LoyaltyEvil {
+		return ErrInvalidAnswer
+	}
+
+	av.record(Event{Type: EventInvestigationAnswered, Player: nick, Claim: shown})
+	return nil
+}
+
+func (av *Avalon) applyInvestigationAnswered(ev Event) {
+	av.PendingAnswer = ""
+	if av.Phase == PhaseAssigned {
+		av.FirstLeaderShown = ev.Claim
+		return
+	}
+
+	av.LakeUses[len(av.LakeUses)-1].Shown = ev.Claim
+	av.startQuest()
+}
@@ -0,0 +1,89 @@
+package avalon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnswerLake(t *testing.T) {
+	avalon := newLakeAvalon()
+	avalon.EnableOption("trickster")
+	avalon.Specials = map[string]string{"trickster": "E"}
+
+	loyalty, err := avalon.UseLake("A", "E")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loyalty != LoyaltyUnknown || avalon.PendingAnswer != "E" || avalon.Phase != PhaseLadyOfTheLake {
+		t.Fatalf("expected to wait for E to answer, got %s with %q pending during %s",
+			loyalty, avalon.PendingAnswer, avalon.Phase)
+	}
+	if _, err := avalon.UseLake("E", "B"); err != ErrAnswerPending {
+		t.Errorf("wanted %v, got %v", ErrAnswerPending, err)
+	}
+
+	var tests = []struct {
+		nick  string
+		shown Loyalty
+		want  error
+	}{
+		{"A", LoyaltyGood, ErrNotInvestigated},
+		{"E", LoyaltyUnknown, ErrInvalidAnswer},
+		{"E", LoyaltyGood, nil},
+	}
+
+	for _, test := range tests {
+		if err := avalon.AnswerInvestigation(test.nick, test.shown); err != test.want {
+			t.Errorf("%s showing %s, wanted %v, got %v", test.nick, test.shown, test.want, err)
+		}
+	}
+
+	if avalon.Phase != PhaseProposing || avalon.CurrentLake != "E" {
+		t.Errorf("expected E holding lake while proposing, got %s during %s", avalon.CurrentLake, avalon.Phase)
+	}
+	if shown := avalon.LakeUses[0].Shown; shown != LoyaltyGood {
+		t.Errorf("expected A to have been shown E's answer, got %s", shown)
+	}
+	if view := avalon.KnowledgeFor("A"); !reflect.DeepEqual(view.Investigated, map[string]Loyalty{"E": LoyaltyGood}) {
+		t.Errorf("expected A to have been shown E as good, got %v", view.Investigated)
+	}
+}
+
+func TestAnswerCleric(t *testing.T) {
+	av := newTestAvalon(t, []string{"A", "B", "C", "D", "E"}, []string{"cleric", "trickster"})
+	assignment := &Assignment{
+		Seats:    []string{"A", "B", "C", "D", "E"},
+		Goods:    []string{"A", "B", "C"},
+		Evils:    []string{"D", "E"},
+		Specials: map[string]string{"merlin": "A", "cleric": "B", "assassin": "D", "trickster": "E"},
+		Leader:   "E",
+	}
+	if err := av.Apply(Event{Type: EventRolesAssigned, Assignment: assignment, Salt: newSalt()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if view := av.KnowledgeFor("B"); view.Investigated != nil {
+		t.Errorf("expected the cleric to see nothing before the trickster answers, got %v", view.Investigated)
+	}
+	if err := av.BeginQuests(); err != ErrAnswerPending {
+		t.Errorf("wanted %v, got %v", ErrAnswerPending, err)
+	}
+
+	if err := av.AnswerInvestigation("E", LoyaltyGood); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if view := av.KnowledgeFor("B"); !reflect.DeepEqual(view.Investigated, map[string]Loyalty{"E": LoyaltyGood}) {
+		t.Errorf("expected the cleric to be shown E as good, got %v", view.Investigated)
+	}
+	if err := av.BeginQuests(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replayed, err := Replay(av.Events())
+	if err != nil {
+		t.Fatalf("unexpected error replaying: %v", err)
+	}
+	if !reflect.DeepEqual(av, replayed) {
+		t.Errorf("replayed game differs from original\noriginal: %+v\nreplayed: %+v", av, replayed)
+	}
+}
@@ -3,24 +3,18 @@ package avalon
 // OptionExists returns whether a given string refers to an option that exists
 // and therefore can be enabled/disabled.
 func OptionExists(target string) bool {
-	for _, option := range availableOptions {
-		if target == option {
-			return true
-		}
-	}
-
-	return false
+	return contains(availableOptions, target) || contains(roleOptions(), target)
 }
 
 // FlavorTextForSpecial returns the flavor text for the specified special
 // character or "" if the character has no flavor text.
 func FlavorTextForSpecial(special string) string {
-	text, ok := specialCharacterToFlavorText[special]
+	role, ok := LookupRole(special)
 	if !ok {
 		return ""
 	}
 
-	return text
+	return role.FlavorText
 }
 
 func numEvils(numPlayers int) int {
@@ -49,3 +43,54 @@ func deleteAt(list []string, i int) []string {
 
 	return append(list[:i], list[i+1:]...)
 }
+
+func contains(list []string, target string) bool {
+	for _, e := range list {
+		if e == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameMembers returns whether a and b hold the same strings the same number of
+// times, in any order.
+func sameMembers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, e := range a {
+		counts[e]++
+	}
+	for _, e := range b {
+		counts[e]--
+		if counts[e] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyStrings(list []string) []string {
+	if list == nil {
+		return nil
+	}
+
+	c := make([]string, len(list))
+	copy(c, list)
+	return c
+}
+
+func copyBools(list []bool) []bool {
+	if list == nil {
+		return nil
+	}
+
+	c := make([]bool, len(list))
+	copy(c, list)
+	return c
+}
@@ -14,6 +14,8 @@ func TestOptionExists(t *testing.T) {
 		{"mordred", true},
 		{"morganapercival", true},
 		{"oberon", true},
+		{"singlefail", true},
+		{"secure", true},
 	}
 
 	for _, test := range tests {
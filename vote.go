@@ -0,0 +1,164 @@
+package avalon
+
+import (
+	"errors"
+)
+
+var (
+	// ErrAlreadyVoted indicates that a player tried to vote twice on the same
+	// proposal.
+	ErrAlreadyVoted = errors.New("avalon: player has already voted on this party")
+)
+
+// Proposal is the record of a single proposed party and how every player
+// voted on it.
+type Proposal struct {
+	Leader    string          `json:"leader"`
+	Quest     int             `json:"quest"`
+	Party     []string        `json:"party"`
+	Excalibur string          `json:"excalibur,omitempty"`
+	Votes     map[string]bool `json:"votes"`
+	Approved  bool            `json:"approved"`
+
+	// Set if the party was approved but then rejected with No Confidence.
+	Overturned bool `json:"overturned,omitempty"`
+}
+
+// Approvals returns the players who voted to approve the party.
+func (p Proposal) Approvals() []string {
+	return p.votersFor(true)
+}
+
+// Rejections returns the players who voted to reject the party.
+func (p Proposal) Rejections() []string {
+	return p.votersFor(false)
+}
+
+func (p Proposal) votersFor(approve bool) []string {
+	var nicks []string
+	for nick, vote := range p.Votes {
+		if vote == approve {
+			nicks = append(nicks, nick)
+		}
+	}
+
+	return nicks
+}
+
+// Vote records a player's approval or rejection of the current proposed
+// party. Once every player has voted the result is computed and the game
+// moves on.
+func (av *Avalon) Vote(nick string, approve bool) error {
+	if err := av.expectPhase("vote", PhaseVoting); err != nil {
+		return err
+	}
+
+	if !av.PlayerExists(nick) {
+		return ErrPlayerNotFound
+	}
+
+	if _, ok := av.CurrentVotes[nick]; ok {
+		return ErrAlreadyVoted
+	}
+
+	if err := av.validateOpinionMakers(nick); err != nil {
+		return err
+	}
+
+	av.record(Event{Type: EventVoteCast, Player: nick, Approve: approve})
+	return nil
+}
+
+func (av *Avalon) applyVoteCast(ev Event) {
+	if av.CurrentVotes == nil {
+		av.CurrentVotes = make(map[string]bool)
+	}
+	av.CurrentVotes[ev.Player] = ev.Approve
+
+	if len(av.CurrentVotes) == av.NumPlayers() {
+		av.resolveVote()
+	}
+}
+
+// CloseVoting computes the result of the current vote without waiting for the
+// remaining players, for frontends whose voting deadline has passed. Players
+// who did not vote are counted as rejecting the party.
+func (av *Avalon) CloseVoting() error {
+	if err := av.expectPhase("close voting", PhaseVoting); err != nil {
+		return err
+	}
+
+	av.record(Event{Type: EventVotingClosed})
+	return nil
+}
+
+func (av *Avalon) applyVotingClosed(ev Event) {
+	for _, nick := range av.Players {
+		if _, ok := av.CurrentVotes[nick]; !ok {
+			if av.CurrentVotes == nil {
+				av.CurrentVotes = make(map[string]bool)
+			}
+			av.CurrentVotes[nick] = false
+		}
+	}
+
+	av.resolveVote()
+}
+
+// resolveVote tallies the current votes. A strict majority of approvals sends
+// the party on the quest.
+func (av *Avalon) resolveVote() {
+	proposal := av.currentProposal()
+	proposal.Approved = len(proposal.Approvals()) > len(proposal.Rejections())
+	av.recordProposal(proposal)
+}
+
+// currentProposal returns the current proposed party and the votes cast on it
+// so far.
+func (av *Avalon) currentProposal() Proposal {
+	return Proposal{
+		Leader:    av.CurrentLeader,
+		Quest:     av.targetQuest(),
+		Party:     av.CurrentProposedParty,
+		Excalibur: av.CurrentExcalibur,
+		Votes:     av.CurrentVotes,
+	}
+}
+
+// recordProposal adds the decided proposal to the quest's history and either
+// sends the party on the quest or rejects it. Leadership passes on either way.
+func (av *Avalon) recordProposal(proposal Proposal) {
+	record := av.currentRecord()
+	record.Quest = proposal.Quest
+	record.Proposals = append(record.Proposals, proposal)
+	av.CurrentVotes = nil
+	av.rotateLeader()
+
+	if proposal.Approved {
+		av.Phase = PhaseQuesting
+		return
+	}
+
+	av.rejectParty()
+}
+
+// rejectParty advances the vote track and goes back to proposing, unless this
+// is the fifth consecutive rejection, which ends the game.
+func (av *Avalon) rejectParty() {
+	av.VoteTrack++
+	av.CurrentProposedParty = nil
+	av.CurrentExcalibur = ""
+
+	if av.VoteTrack >= MaxRejections {
+		av.Phase = PhaseGameOver
+		return
+	}
+
+	av.startRound()
+}
+
+// rotateLeader passes leadership clockwise to the player on the current
+// leader's left.
+func (av *Avalon) rotateLeader() {
+	av.CurrentLeader = av.NextLeader()
+}
@@ -0,0 +1,135 @@
+package avalon
+
+import (
+	"testing"
+)
+
+func newVotingAvalon() *Avalon {
+	avalon := NewAvalon()
+	avalon.Players = []string{"A", "B", "C", "D", "E"}
+	avalon.Seats = avalon.Players
+	avalon.Phase = PhaseVoting
+	avalon.CurrentLeader = "A"
+	avalon.CurrentProposedParty = []string{"A", "B"}
+
+	return avalon
+}
+
+func TestVote(t *testing.T) {
+	var tests = []struct {
+		votes     []bool
+		wantPhase Phase
+		wantTrack int
+	}{
+		{[]bool{true, true, true, false, false}, PhaseQuesting, 0},
+		{[]bool{true, true, false, false, false}, PhaseProposing, 1},
+		{[]bool{false, false, false, false, false}, PhaseProposing, 1},
+	}
+
+	for _, test := range tests {
+		avalon := newVotingAvalon()
+
+		for i, vote := range test.votes {
+			if err := avalon.Vote(avalon.Players[i], vote); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if avalon.Phase != test.wantPhase {
+			t.Errorf("wanted %s for votes %v, got %s", test.wantPhase, test.votes, avalon.Phase)
+		}
+		if avalon.VoteTrack != test.wantTrack {
+			t.Errorf("wanted vote track %d for votes %v, got %d", test.wantTrack, test.votes, avalon.VoteTrack)
+		}
+		if avalon.CurrentLeader != "B" {
+			t.Errorf("expected leadership to pass to B, got %s", avalon.CurrentLeader)
+		}
+		if len(avalon.Proposals()) != 1 {
+			t.Fatalf("expected 1 proposal recorded, got %d", len(avalon.Proposals()))
+		}
+
+		proposal := avalon.Proposals()[0]
+		if proposal.Leader != "A" || len(proposal.Votes) != 5 {
+			t.Errorf("unexpected proposal recorded: %+v", proposal)
+		}
+		for i, vote := range test.votes {
+			if proposal.Votes[avalon.Players[i]] != vote {
+				t.Errorf("expected %s to have voted %t", avalon.Players[i], vote)
+			}
+		}
+	}
+}
+
+func TestVoteErrors(t *testing.T) {
+	avalon := newVotingAvalon()
+
+	if err := avalon.Vote("Z", true); err != ErrPlayerNotFound {
+		t.Errorf("wanted %v, got %v", ErrPlayerNotFound, err)
+	}
+
+	if err := avalon.Vote("A", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avalon.Vote("A", false); err != ErrAlreadyVoted {
+		t.Errorf("wanted %v, got %v", ErrAlreadyVoted, err)
+	}
+	if !avalon.CurrentVotes["A"] {
+		t.Error("expected A's original vote to stand")
+	}
+}
+
+func TestCloseVoting(t *testing.T) {
+	avalon := newVotingAvalon()
+	if err := avalon.Vote("A", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avalon.Vote("B", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := avalon.Vote("C", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := avalon.CloseVoting(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if avalon.Phase != PhaseQuesting {
+		t.Errorf("expected %s, got %s", PhaseQuesting, avalon.Phase)
+	}
+	if votes := avalon.Proposals()[0].Votes; len(votes) != 5 || votes["D"] || votes["E"] {
+		t.Errorf("expected missing votes to count as rejections, got %v", votes)
+	}
+}
+
+func TestFiveRejections(t *testing.T) {
+	avalon := newVotingAvalon()
+	avalon.Phase = PhaseProposing
+
+	for i := 0; i < MaxRejections; i++ {
+		if err := avalon.ProposeParty(avalon.CurrentLeader, []string{"A", "B"}); err != nil {
+			t.Fatalf("unexpected error proposing: %v", err)
+		}
+		for _, nick := range avalon.Players {
+			if err := avalon.Vote(nick, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if avalon.Phase != PhaseGameOver {
+		t.Errorf("expected %s after %d rejections, got %s", PhaseGameOver, MaxRejections, avalon.Phase)
+	}
+	if result, _ := avalon.Result(); result.Reason != ReasonFiveRejections {
+		t.Errorf("expected %s, got %s", ReasonFiveRejections, result.Reason)
+	}
+	if len(avalon.Proposals()) != MaxRejections {
+		t.Errorf("expected %d proposals recorded, got %d", MaxRejections, len(avalon.Proposals()))
+	}
+
+	leaders := []string{"A", "B", "C", "D", "E"}
+	for i, proposal := range avalon.Proposals() {
+		if proposal.Leader != leaders[i] {
+			t.Errorf("expected proposal %d led by %s, got %s", i, leaders[i], proposal.Leader)
+		}
+	}
+}